@@ -2,22 +2,63 @@ package main
 
 import (
 	mysql "e-course-management/pkg/db/mysql"
+	"e-course-management/pkg/payment"
 	"github.com/gin-gonic/gin"
 
 	forgotPassword "e-course-management/internal/forgot_password/injector"
 	oauth "e-course-management/internal/oauth/injector"
 	register "e-course-management/internal/register/injector"
 	admin "e-course-management/internal/admin/injector"
+	cart "e-course-management/internal/cart/injector"
+	certificate "e-course-management/internal/certificate/injector"
+	classRoom "e-course-management/internal/class_room/injector"
+	curriculum "e-course-management/internal/curriculum/injector"
+	discount "e-course-management/internal/discount/injector"
+	emailOutbox "e-course-management/internal/email_outbox/injector"
+	emailSuppression "e-course-management/internal/email_suppression/injector"
+	emailTemplate "e-course-management/internal/email_template/injector"
+	instructor "e-course-management/internal/instructor/injector"
+	lessonProgress "e-course-management/internal/lesson_progress/injector"
+	media "e-course-management/internal/media/injector"
+	order "e-course-management/internal/order/injector"
+	orderNotification "e-course-management/internal/order_notification/injector"
+	product "e-course-management/internal/product/injector"
+	productCategory "e-course-management/internal/product_category/injector"
+	quiz "e-course-management/internal/quiz/injector"
+	review "e-course-management/internal/review/injector"
 )
 
 func main() {
 	r := gin.Default()
 	db := mysql.DB()
+	// One provider for the checkout and the expiry worker, so that the fake
+	// one keeps the invoices of both in the same memory
+	paymentProvider := payment.NewPayment()
 
 	forgotPassword.InitializedService(db).Route(&r.RouterGroup)
 	oauth.InitializedService(db).Route(&r.RouterGroup)
 	register.InitializedService(db).Route(&r.RouterGroup)
 	admin.InitializedService(db).Route(&r.RouterGroup)
+	emailOutbox.InitializedService(db).Route(&r.RouterGroup)
+	emailTemplate.InitializedService().Route(&r.RouterGroup)
+	emailSuppression.InitializedService(db).Route(&r.RouterGroup)
+	productCategory.InitializedService(db).Route(&r.RouterGroup)
+	product.InitializedService(db).Route(&r.RouterGroup)
+	media.InitializedService(db).Route(&r.RouterGroup)
+	curriculum.InitializedService(db).Route(&r.RouterGroup)
+	lessonProgress.InitializedService(db).Route(&r.RouterGroup)
+	certificate.InitializedService(db).Route(&r.RouterGroup)
+	quiz.InitializedService(db).Route(&r.RouterGroup)
+	review.InitializedService(db).Route(&r.RouterGroup)
+	instructor.InitializedService(db).Route(&r.RouterGroup)
+	cart.InitializedService(db).Route(&r.RouterGroup)
+	discount.InitializedService(db).Route(&r.RouterGroup)
+	order.InitializedService(db, paymentProvider).Route(&r.RouterGroup)
+	classRoom.InitializedService(db).Route(&r.RouterGroup)
+
+	go emailOutbox.InitializedWorker(db).Run()
+	go orderNotification.InitializedWorker(db).Run()
+	go order.InitializedWorker(db, paymentProvider).Run()
 
 	r.Run()
 }
@@ -0,0 +1,19 @@
+CREATE TABLE email_outboxes (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `to_email` VARCHAR ( 255 ) NOT NULL,
+    `subject` VARCHAR ( 255 ) NOT NULL,
+    `template` VARCHAR ( 255 ) NOT NULL,
+    `html_body` MEDIUMTEXT NOT NULL,
+    `status` VARCHAR ( 255 ) NOT NULL,
+    `attempts` INT NOT NULL default 0,
+    `max_attempts` INT NOT NULL default 5,
+    `next_attempt_at` TIMESTAMP NULL,
+    `last_error` TEXT NULL,
+    `sent_at` TIMESTAMP NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NULL,
+    `deleted_at` TIMESTAMP NULL,
+    PRIMARY KEY ( `id` ),
+    INDEX idx_email_outboxes_to_email ( `to_email` ) ,
+    INDEX idx_email_outboxes_status_next_attempt_at ( `status`, `next_attempt_at` )
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = utf8;
@@ -0,0 +1,3 @@
+ALTER TABLE email_outboxes
+    DROP COLUMN `text_body`,
+    DROP COLUMN `locale`;
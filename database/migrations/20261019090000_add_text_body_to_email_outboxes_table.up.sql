@@ -0,0 +1,3 @@
+ALTER TABLE email_outboxes
+    ADD COLUMN `text_body` MEDIUMTEXT NULL AFTER `html_body`,
+    ADD COLUMN `locale` VARCHAR ( 10 ) NOT NULL default 'id' AFTER `template`;
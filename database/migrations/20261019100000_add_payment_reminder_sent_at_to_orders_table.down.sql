@@ -0,0 +1,3 @@
+ALTER TABLE orders
+    DROP INDEX idx_orders_status_created_at,
+    DROP COLUMN `payment_reminder_sent_at`;
@@ -0,0 +1,3 @@
+ALTER TABLE orders
+    ADD COLUMN `payment_reminder_sent_at` TIMESTAMP NULL AFTER `status`,
+    ADD INDEX idx_orders_status_created_at ( `status`, `created_at` );
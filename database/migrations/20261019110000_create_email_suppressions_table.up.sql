@@ -0,0 +1,13 @@
+CREATE TABLE email_suppressions (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `email` VARCHAR ( 255 ) NOT NULL,
+    `reason` VARCHAR ( 255 ) NOT NULL,
+    `detail` TEXT NULL,
+    `sg_event_id` VARCHAR ( 255 ) NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NULL,
+    `deleted_at` TIMESTAMP NULL,
+    PRIMARY KEY ( `id` ),
+    UNIQUE KEY email_suppressions_email_unique ( `email` ),
+    INDEX idx_email_suppressions_reason ( `reason` )
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = utf8;
@@ -0,0 +1,3 @@
+ALTER TABLE users
+    DROP COLUMN `email_suppressed_at`,
+    DROP COLUMN `email_suppression_reason`;
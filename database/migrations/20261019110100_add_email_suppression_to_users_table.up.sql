@@ -0,0 +1,3 @@
+ALTER TABLE users
+    ADD COLUMN `email_suppressed_at` TIMESTAMP NULL AFTER `email_verified_at`,
+    ADD COLUMN `email_suppression_reason` VARCHAR ( 255 ) NULL AFTER `email_suppressed_at`;
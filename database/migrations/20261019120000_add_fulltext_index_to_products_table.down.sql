@@ -0,0 +1,4 @@
+ALTER TABLE products
+    DROP INDEX ft_products_title_description,
+    DROP INDEX ft_products_title,
+    MODIFY `description` VARCHAR ( 255 ) NULL;
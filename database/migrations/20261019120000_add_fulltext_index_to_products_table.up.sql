@@ -0,0 +1,4 @@
+ALTER TABLE products
+    MODIFY `description` TEXT NULL,
+    ADD FULLTEXT INDEX ft_products_title ( `title` ),
+    ADD FULLTEXT INDEX ft_products_title_description ( `title`, `description` );
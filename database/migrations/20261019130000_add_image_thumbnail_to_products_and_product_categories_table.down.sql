@@ -0,0 +1,4 @@
+ALTER TABLE products
+    DROP COLUMN `image_thumbnail`;
+ALTER TABLE product_categories
+    DROP COLUMN `image_thumbnail`;
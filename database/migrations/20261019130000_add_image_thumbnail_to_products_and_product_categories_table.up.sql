@@ -0,0 +1,4 @@
+ALTER TABLE product_categories
+    ADD COLUMN `image_thumbnail` VARCHAR ( 255 ) NULL AFTER `image`;
+ALTER TABLE products
+    ADD COLUMN `image_thumbnail` VARCHAR ( 255 ) NULL AFTER `image`;
@@ -0,0 +1,18 @@
+CREATE TABLE sections (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `product_id` INT NOT NULL,
+    `title` VARCHAR ( 255 ) NOT NULL,
+    `position` INT NOT NULL DEFAULT 0,
+    `created_by` INT NULL,
+    `updated_by` INT NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NULL,
+    `deleted_at` TIMESTAMP NULL,
+    PRIMARY KEY ( `id` ),
+    INDEX idx_sections_product_id_position ( `product_id`, `position` ) ,
+    INDEX idx_sections_created_by ( `created_by` ) ,
+    INDEX idx_sections_updated_by ( `updated_by` ) ,
+    CONSTRAINT FK_sections_product_id FOREIGN KEY (`product_id`) REFERENCES products(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_sections_created_by FOREIGN KEY (`created_by`) REFERENCES admins(`id`)  ON DELETE SET NULL,
+    CONSTRAINT FK_sections_updated_by FOREIGN KEY (`updated_by`) REFERENCES admins(`id`)  ON DELETE SET NULL
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = utf8;
@@ -0,0 +1,24 @@
+CREATE TABLE lessons (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `section_id` INT NOT NULL,
+    `title` VARCHAR ( 255 ) NOT NULL,
+    `type` ENUM ( 'video', 'article', 'quiz', 'attachment' ) NOT NULL,
+    `position` INT NOT NULL DEFAULT 0,
+    `duration` INT NOT NULL DEFAULT 0,
+    `is_free_preview` boolean DEFAULT 0 NOT NULL,
+    `video` VARCHAR ( 255 ) NULL,
+    `content` MEDIUMTEXT NULL,
+    `attachment` VARCHAR ( 255 ) NULL,
+    `created_by` INT NULL,
+    `updated_by` INT NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NULL,
+    `deleted_at` TIMESTAMP NULL,
+    PRIMARY KEY ( `id` ),
+    INDEX idx_lessons_section_id_position ( `section_id`, `position` ) ,
+    INDEX idx_lessons_created_by ( `created_by` ) ,
+    INDEX idx_lessons_updated_by ( `updated_by` ) ,
+    CONSTRAINT FK_lessons_section_id FOREIGN KEY (`section_id`) REFERENCES sections(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_lessons_created_by FOREIGN KEY (`created_by`) REFERENCES admins(`id`)  ON DELETE SET NULL,
+    CONSTRAINT FK_lessons_updated_by FOREIGN KEY (`updated_by`) REFERENCES admins(`id`)  ON DELETE SET NULL
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = utf8;
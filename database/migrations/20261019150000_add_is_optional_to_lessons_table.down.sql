@@ -0,0 +1,2 @@
+ALTER TABLE lessons
+    DROP COLUMN `is_optional`;
@@ -0,0 +1,2 @@
+ALTER TABLE lessons
+    ADD COLUMN `is_optional` boolean DEFAULT 0 NOT NULL AFTER `is_free_preview`;
@@ -0,0 +1,3 @@
+ALTER TABLE class_rooms
+    DROP COLUMN `completed_at`,
+    DROP COLUMN `progress`;
@@ -0,0 +1,3 @@
+ALTER TABLE class_rooms
+    ADD COLUMN `progress` INT NOT NULL DEFAULT 0 AFTER `product_id`,
+    ADD COLUMN `completed_at` TIMESTAMP NULL AFTER `progress`;
@@ -0,0 +1,18 @@
+CREATE TABLE lesson_progresses (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `user_id` INT NOT NULL,
+    `class_room_id` INT NOT NULL,
+    `lesson_id` INT NOT NULL,
+    `last_position` INT NOT NULL DEFAULT 0,
+    `started_at` TIMESTAMP NULL,
+    `completed_at` TIMESTAMP NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NULL,
+    PRIMARY KEY ( `id` ),
+    UNIQUE KEY lesson_progresses_class_room_id_lesson_id_unique ( `class_room_id`, `lesson_id` ),
+    INDEX idx_lesson_progresses_user_id_updated_at ( `user_id`, `updated_at` ) ,
+    INDEX idx_lesson_progresses_lesson_id ( `lesson_id` ) ,
+    CONSTRAINT FK_lesson_progresses_user_id FOREIGN KEY (`user_id`) REFERENCES users(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_lesson_progresses_class_room_id FOREIGN KEY (`class_room_id`) REFERENCES class_rooms(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_lesson_progresses_lesson_id FOREIGN KEY (`lesson_id`) REFERENCES lessons(`id`)  ON DELETE CASCADE
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = utf8;
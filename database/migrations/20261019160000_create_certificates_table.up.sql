@@ -0,0 +1,21 @@
+CREATE TABLE certificates (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `serial` VARCHAR ( 32 ) NOT NULL,
+    `user_id` INT NOT NULL,
+    `class_room_id` INT NOT NULL,
+    `product_id` INT NOT NULL,
+    `user_name` VARCHAR ( 255 ) NOT NULL,
+    `course_title` VARCHAR ( 255 ) NOT NULL,
+    `completed_at` TIMESTAMP NOT NULL,
+    `file` VARCHAR ( 255 ) NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NULL,
+    PRIMARY KEY ( `id` ),
+    UNIQUE KEY certificates_serial_unique ( `serial` ),
+    UNIQUE KEY certificates_class_room_id_unique ( `class_room_id` ),
+    INDEX idx_certificates_user_id ( `user_id` ) ,
+    INDEX idx_certificates_product_id ( `product_id` ) ,
+    CONSTRAINT FK_certificates_user_id FOREIGN KEY (`user_id`) REFERENCES users(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_certificates_class_room_id FOREIGN KEY (`class_room_id`) REFERENCES class_rooms(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_certificates_product_id FOREIGN KEY (`product_id`) REFERENCES products(`id`)  ON DELETE CASCADE
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = utf8;
@@ -0,0 +1,20 @@
+CREATE TABLE quizzes (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `lesson_id` INT NOT NULL,
+    `passing_score` INT NOT NULL DEFAULT 0,
+    `max_attempts` INT NULL,
+    `time_limit` INT NULL,
+    `question_count` INT NULL,
+    `created_by` INT NULL,
+    `updated_by` INT NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NULL,
+    `deleted_at` TIMESTAMP NULL,
+    PRIMARY KEY ( `id` ),
+    UNIQUE KEY quizzes_lesson_id_unique ( `lesson_id` ),
+    INDEX idx_quizzes_created_by ( `created_by` ) ,
+    INDEX idx_quizzes_updated_by ( `updated_by` ) ,
+    CONSTRAINT FK_quizzes_lesson_id FOREIGN KEY (`lesson_id`) REFERENCES lessons(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_quizzes_created_by FOREIGN KEY (`created_by`) REFERENCES admins(`id`)  ON DELETE SET NULL,
+    CONSTRAINT FK_quizzes_updated_by FOREIGN KEY (`updated_by`) REFERENCES admins(`id`)  ON DELETE SET NULL
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = utf8;
@@ -0,0 +1,18 @@
+CREATE TABLE quiz_questions (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `quiz_id` INT NOT NULL,
+    `type` ENUM ( 'single_choice', 'multiple_choice', 'short_answer' ) NOT NULL,
+    `question` TEXT NOT NULL,
+    `points` INT NOT NULL DEFAULT 1,
+    `position` INT NOT NULL DEFAULT 0,
+    `created_by` INT NULL,
+    `updated_by` INT NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NULL,
+    `deleted_at` TIMESTAMP NULL,
+    PRIMARY KEY ( `id` ),
+    INDEX idx_quiz_questions_quiz_id_position ( `quiz_id`, `position` ) ,
+    CONSTRAINT FK_quiz_questions_quiz_id FOREIGN KEY (`quiz_id`) REFERENCES quizzes(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_quiz_questions_created_by FOREIGN KEY (`created_by`) REFERENCES admins(`id`)  ON DELETE SET NULL,
+    CONSTRAINT FK_quiz_questions_updated_by FOREIGN KEY (`updated_by`) REFERENCES admins(`id`)  ON DELETE SET NULL
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = utf8;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS quiz_question_options;
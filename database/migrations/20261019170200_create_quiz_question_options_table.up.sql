@@ -0,0 +1,13 @@
+CREATE TABLE quiz_question_options (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `quiz_question_id` INT NOT NULL,
+    `content` TEXT NOT NULL,
+    `is_correct` boolean DEFAULT 0 NOT NULL,
+    `position` INT NOT NULL DEFAULT 0,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NULL,
+    `deleted_at` TIMESTAMP NULL,
+    PRIMARY KEY ( `id` ),
+    INDEX idx_quiz_question_options_quiz_question_id ( `quiz_question_id` ) ,
+    CONSTRAINT FK_quiz_question_options_quiz_question_id FOREIGN KEY (`quiz_question_id`) REFERENCES quiz_questions(`id`)  ON DELETE CASCADE
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = utf8;
@@ -0,0 +1,23 @@
+CREATE TABLE quiz_attempts (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `quiz_id` INT NOT NULL,
+    `user_id` INT NOT NULL,
+    `class_room_id` INT NOT NULL,
+    `status` ENUM ( 'in_progress', 'submitted', 'expired' ) NOT NULL DEFAULT 'in_progress',
+    `question_ids` JSON NOT NULL,
+    `started_at` TIMESTAMP NOT NULL,
+    `expires_at` TIMESTAMP NULL,
+    `submitted_at` TIMESTAMP NULL,
+    `score` INT NOT NULL DEFAULT 0,
+    `max_score` INT NOT NULL DEFAULT 0,
+    `percentage` INT NOT NULL DEFAULT 0,
+    `is_passed` boolean DEFAULT 0 NOT NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NULL,
+    PRIMARY KEY ( `id` ),
+    INDEX idx_quiz_attempts_quiz_id_class_room_id ( `quiz_id`, `class_room_id` ) ,
+    INDEX idx_quiz_attempts_user_id ( `user_id` ) ,
+    CONSTRAINT FK_quiz_attempts_quiz_id FOREIGN KEY (`quiz_id`) REFERENCES quizzes(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_quiz_attempts_user_id FOREIGN KEY (`user_id`) REFERENCES users(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_quiz_attempts_class_room_id FOREIGN KEY (`class_room_id`) REFERENCES class_rooms(`id`)  ON DELETE CASCADE
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = utf8;
@@ -0,0 +1,15 @@
+CREATE TABLE quiz_attempt_answers (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `quiz_attempt_id` INT NOT NULL,
+    `quiz_question_id` INT NOT NULL,
+    `option_ids` JSON NULL,
+    `answer` TEXT NULL,
+    `is_correct` boolean DEFAULT 0 NOT NULL,
+    `points` INT NOT NULL DEFAULT 0,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NULL,
+    PRIMARY KEY ( `id` ),
+    UNIQUE KEY quiz_attempt_answers_attempt_question_unique ( `quiz_attempt_id`, `quiz_question_id` ),
+    CONSTRAINT FK_quiz_attempt_answers_quiz_attempt_id FOREIGN KEY (`quiz_attempt_id`) REFERENCES quiz_attempts(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_quiz_attempt_answers_quiz_question_id FOREIGN KEY (`quiz_question_id`) REFERENCES quiz_questions(`id`)  ON DELETE CASCADE
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = utf8;
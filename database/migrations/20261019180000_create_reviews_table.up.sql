@@ -0,0 +1,22 @@
+CREATE TABLE reviews (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `product_id` INT NOT NULL,
+    `user_id` INT NOT NULL,
+    `class_room_id` INT NOT NULL,
+    `rating` TINYINT NOT NULL,
+    `comment` TEXT NULL,
+    `is_hidden` boolean DEFAULT 0 NOT NULL,
+    `hidden_reason` VARCHAR ( 255 ) NULL,
+    `hidden_at` TIMESTAMP NULL,
+    `hidden_by` INT NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NULL,
+    PRIMARY KEY ( `id` ),
+    UNIQUE KEY reviews_product_id_user_id_unique ( `product_id`, `user_id` ),
+    INDEX idx_reviews_product_id_is_hidden ( `product_id`, `is_hidden` ) ,
+    INDEX idx_reviews_user_id ( `user_id` ) ,
+    CONSTRAINT FK_reviews_product_id FOREIGN KEY (`product_id`) REFERENCES products(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_reviews_user_id FOREIGN KEY (`user_id`) REFERENCES users(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_reviews_class_room_id FOREIGN KEY (`class_room_id`) REFERENCES class_rooms(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_reviews_hidden_by FOREIGN KEY (`hidden_by`) REFERENCES admins(`id`)  ON DELETE SET NULL
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = utf8;
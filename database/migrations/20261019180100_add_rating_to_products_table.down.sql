@@ -0,0 +1,4 @@
+ALTER TABLE products
+    DROP INDEX idx_products_average_rating,
+    DROP COLUMN `review_count`,
+    DROP COLUMN `average_rating`;
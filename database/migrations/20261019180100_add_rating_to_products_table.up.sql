@@ -0,0 +1,4 @@
+ALTER TABLE products
+    ADD COLUMN `average_rating` DECIMAL ( 3, 2 ) NOT NULL DEFAULT 0 AFTER `price`,
+    ADD COLUMN `review_count` INT NOT NULL DEFAULT 0 AFTER `average_rating`,
+    ADD INDEX idx_products_average_rating ( `average_rating` );
@@ -0,0 +1,18 @@
+CREATE TABLE instructors (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `user_id` INT NOT NULL,
+    `headline` VARCHAR ( 255 ) NULL,
+    `bio` TEXT NULL,
+    `created_by` INT NULL,
+    `updated_by` INT NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NULL,
+    `deleted_at` TIMESTAMP NULL,
+    PRIMARY KEY ( `id` ),
+    UNIQUE KEY instructors_user_id_unique ( `user_id` ),
+    INDEX idx_instructors_created_by ( `created_by` ) ,
+    INDEX idx_instructors_updated_by ( `updated_by` ) ,
+    CONSTRAINT FK_instructors_user_id FOREIGN KEY (`user_id`) REFERENCES users(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_instructors_created_by FOREIGN KEY (`created_by`) REFERENCES admins(`id`)  ON DELETE SET NULL,
+    CONSTRAINT FK_instructors_updated_by FOREIGN KEY (`updated_by`) REFERENCES admins(`id`)  ON DELETE SET NULL
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = utf8;
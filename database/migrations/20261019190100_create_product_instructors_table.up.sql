@@ -0,0 +1,11 @@
+CREATE TABLE product_instructors (
+    `product_id` INT NOT NULL,
+    `instructor_id` INT NOT NULL,
+    `created_by` INT NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ( `product_id`, `instructor_id` ),
+    INDEX idx_product_instructors_instructor_id ( `instructor_id` ) ,
+    CONSTRAINT FK_product_instructors_product_id FOREIGN KEY (`product_id`) REFERENCES products(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_product_instructors_instructor_id FOREIGN KEY (`instructor_id`) REFERENCES instructors(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_product_instructors_created_by FOREIGN KEY (`created_by`) REFERENCES admins(`id`)  ON DELETE SET NULL
+) ENGINE = INNODB DEFAULT CHARSET = utf8;
@@ -0,0 +1,2 @@
+ALTER TABLE carts
+    DROP INDEX carts_user_id_product_id_unique;
@@ -0,0 +1,4 @@
+DELETE FROM carts WHERE `deleted_at` IS NOT NULL;
+
+ALTER TABLE carts
+    ADD UNIQUE KEY carts_user_id_product_id_unique ( `user_id`, `product_id` );
@@ -0,0 +1,13 @@
+CREATE TABLE guest_carts (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `guest_id` VARCHAR ( 64 ) NOT NULL,
+    `product_id` INT NOT NULL,
+    `is_checked` boolean NOT NULL default 1,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NULL,
+    PRIMARY KEY ( `id` ),
+    UNIQUE KEY guest_carts_guest_id_product_id_unique ( `guest_id`, `product_id` ),
+    INDEX idx_guest_carts_product_id ( `product_id` ) ,
+    INDEX idx_guest_carts_updated_at ( `updated_at` ) ,
+    CONSTRAINT FK_guest_carts_product_id FOREIGN KEY (`product_id`) REFERENCES products(`id`)  ON DELETE CASCADE
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = utf8;
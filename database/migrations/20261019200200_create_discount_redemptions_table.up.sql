@@ -0,0 +1,15 @@
+CREATE TABLE discount_redemptions (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `discount_id` INT NOT NULL,
+    `order_id` INT NOT NULL,
+    `user_id` INT NULL,
+    `released_at` TIMESTAMP NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ( `id` ),
+    UNIQUE KEY discount_redemptions_discount_id_order_id_unique ( `discount_id`, `order_id` ),
+    INDEX idx_discount_redemptions_order_id ( `order_id` ) ,
+    INDEX idx_discount_redemptions_user_id ( `user_id` ) ,
+    CONSTRAINT FK_discount_redemptions_discount_id FOREIGN KEY (`discount_id`) REFERENCES discounts(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_discount_redemptions_order_id FOREIGN KEY (`order_id`) REFERENCES orders(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_discount_redemptions_user_id FOREIGN KEY (`user_id`) REFERENCES users(`id`)  ON DELETE SET NULL
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = utf8;
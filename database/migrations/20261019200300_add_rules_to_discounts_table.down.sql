@@ -0,0 +1,6 @@
+ALTER TABLE discounts
+    DROP COLUMN `is_stackable`,
+    DROP COLUMN `first_order_only`,
+    DROP COLUMN `once_per_user`,
+    DROP COLUMN `maximum_reduction`,
+    DROP COLUMN `minimum_spend`;
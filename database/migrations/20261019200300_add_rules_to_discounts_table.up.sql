@@ -0,0 +1,6 @@
+ALTER TABLE discounts
+    ADD COLUMN `minimum_spend` INT NULL AFTER `end_date`,
+    ADD COLUMN `maximum_reduction` INT NULL AFTER `minimum_spend`,
+    ADD COLUMN `once_per_user` boolean NOT NULL DEFAULT 0 AFTER `maximum_reduction`,
+    ADD COLUMN `first_order_only` boolean NOT NULL DEFAULT 0 AFTER `once_per_user`,
+    ADD COLUMN `is_stackable` boolean NOT NULL DEFAULT 0 AFTER `first_order_only`;
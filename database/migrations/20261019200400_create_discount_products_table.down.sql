@@ -0,0 +1 @@
+DROP TABLE IF EXISTS discount_products;
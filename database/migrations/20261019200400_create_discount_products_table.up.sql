@@ -0,0 +1,8 @@
+CREATE TABLE discount_products (
+    `discount_id` INT NOT NULL,
+    `product_id` INT NOT NULL,
+    PRIMARY KEY ( `discount_id`, `product_id` ),
+    INDEX idx_discount_products_product_id ( `product_id` ) ,
+    CONSTRAINT FK_discount_products_discount_id FOREIGN KEY (`discount_id`) REFERENCES discounts(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_discount_products_product_id FOREIGN KEY (`product_id`) REFERENCES products(`id`)  ON DELETE CASCADE
+) ENGINE = INNODB DEFAULT CHARSET = utf8;
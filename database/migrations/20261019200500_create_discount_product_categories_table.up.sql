@@ -0,0 +1,8 @@
+CREATE TABLE discount_product_categories (
+    `discount_id` INT NOT NULL,
+    `product_category_id` INT NOT NULL,
+    PRIMARY KEY ( `discount_id`, `product_category_id` ),
+    INDEX idx_discount_product_categories_product_category_id ( `product_category_id` ) ,
+    CONSTRAINT FK_discount_product_categories_discount_id FOREIGN KEY (`discount_id`) REFERENCES discounts(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_discount_product_categories_product_category_id FOREIGN KEY (`product_category_id`) REFERENCES product_categories(`id`)  ON DELETE CASCADE
+) ENGINE = INNODB DEFAULT CHARSET = utf8;
@@ -0,0 +1,2 @@
+ALTER TABLE class_rooms
+    DROP INDEX class_rooms_user_id_product_id_unique;
@@ -0,0 +1,8 @@
+DELETE duplicate FROM class_rooms duplicate
+    JOIN class_rooms original
+        ON original.`user_id` = duplicate.`user_id`
+        AND original.`product_id` = duplicate.`product_id`
+        AND original.`id` < duplicate.`id`;
+
+ALTER TABLE class_rooms
+    ADD UNIQUE KEY class_rooms_user_id_product_id_unique ( `user_id`, `product_id` );
@@ -0,0 +1,2 @@
+ALTER TABLE orders
+    MODIFY COLUMN `status` VARCHAR ( 255 ) NOT NULL;
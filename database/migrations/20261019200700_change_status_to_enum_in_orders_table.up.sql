@@ -0,0 +1,4 @@
+UPDATE orders SET `status` = 'cancelled' WHERE `status` = 'failed';
+
+ALTER TABLE orders
+    MODIFY COLUMN `status` ENUM ( 'pending', 'paid', 'expired', 'cancelled', 'refunded', 'partially_refunded' ) NOT NULL DEFAULT 'pending';
@@ -0,0 +1,18 @@
+CREATE TABLE order_status_histories (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `order_id` INT NOT NULL,
+    `from_status` VARCHAR ( 32 ) NULL,
+    `to_status` VARCHAR ( 32 ) NOT NULL,
+    `source` VARCHAR ( 32 ) NOT NULL,
+    `reason` VARCHAR ( 255 ) NULL,
+    `changed_by` INT NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ( `id` ),
+    INDEX idx_order_status_histories_order_id ( `order_id` ) ,
+    INDEX idx_order_status_histories_changed_by ( `changed_by` ) ,
+    CONSTRAINT FK_order_status_histories_order_id FOREIGN KEY (`order_id`) REFERENCES orders(`id`)  ON DELETE CASCADE,
+    CONSTRAINT FK_order_status_histories_changed_by FOREIGN KEY (`changed_by`) REFERENCES users(`id`)  ON DELETE SET NULL
+) ENGINE = INNODB AUTO_INCREMENT = 1 DEFAULT CHARSET = utf8;
+
+INSERT INTO order_status_histories ( `order_id`, `to_status`, `source`, `reason`, `created_at` )
+    SELECT `id`, `status`, 'system', 'status before history was recorded', COALESCE( `updated_at`, `created_at` ) FROM orders;
@@ -0,0 +1,4 @@
+ALTER TABLE order_status_histories
+    DROP FOREIGN KEY FK_order_status_histories_changed_by_admin,
+    DROP INDEX idx_order_status_histories_changed_by_admin,
+    DROP COLUMN `changed_by_admin`;
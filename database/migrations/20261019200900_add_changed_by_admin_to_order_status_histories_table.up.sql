@@ -0,0 +1,4 @@
+ALTER TABLE order_status_histories
+    ADD COLUMN `changed_by_admin` INT NULL AFTER `changed_by`,
+    ADD INDEX idx_order_status_histories_changed_by_admin ( `changed_by_admin` ),
+    ADD CONSTRAINT FK_order_status_histories_changed_by_admin FOREIGN KEY (`changed_by_admin`) REFERENCES admins(`id`)  ON DELETE SET NULL;
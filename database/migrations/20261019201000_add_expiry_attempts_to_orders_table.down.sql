@@ -0,0 +1,4 @@
+ALTER TABLE orders
+    DROP INDEX idx_orders_status_created_at,
+    DROP COLUMN `next_expiry_attempt_at`,
+    DROP COLUMN `expiry_attempts`;
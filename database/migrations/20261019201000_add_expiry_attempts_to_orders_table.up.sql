@@ -0,0 +1,4 @@
+ALTER TABLE orders
+    ADD COLUMN `expiry_attempts` INT NOT NULL DEFAULT 0 AFTER `payment_reminder_sent_at`,
+    ADD COLUMN `next_expiry_attempt_at` TIMESTAMP NULL AFTER `expiry_attempts`,
+    ADD INDEX idx_orders_status_created_at ( `status`, `created_at` );
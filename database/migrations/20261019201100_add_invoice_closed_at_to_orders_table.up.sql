@@ -0,0 +1,7 @@
+ALTER TABLE orders
+    ADD COLUMN `invoice_closed_at` TIMESTAMP NULL AFTER `next_expiry_attempt_at`;
+
+-- Invoices of orders given up so far were closed along with the status change
+UPDATE orders
+SET invoice_closed_at = updated_at
+WHERE status IN ('expired', 'cancelled') AND external_id IS NOT NULL;
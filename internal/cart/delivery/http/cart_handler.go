@@ -0,0 +1,245 @@
+package cart
+
+import (
+	"net/http"
+	"strconv"
+
+	dto "e-course-management/internal/cart/dto"
+	usecase "e-course-management/internal/cart/usecase"
+	"e-course-management/internal/middleware"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CartHandler struct {
+	usecase usecase.CartUseCase
+}
+
+func NewCartHandler(usecase usecase.CartUseCase) *CartHandler {
+	return &CartHandler{usecase}
+}
+
+func (handler *CartHandler) Route(r *gin.RouterGroup) {
+	cartRouter := r.Group("/api/v1")
+
+	cartRouter.GET("/guest_carts", handler.FindAllGuest)
+	cartRouter.POST("/guest_carts", handler.CreateGuest)
+	cartRouter.PATCH("/guest_carts/:id", handler.CheckGuest)
+	cartRouter.DELETE("/guest_carts/:id", handler.DeleteGuest)
+
+	cartRouter.Use(middleware.AuthJwt, middleware.AuthUser)
+	{
+		cartRouter.GET("/carts", handler.FindAll)
+		cartRouter.POST("/carts", handler.Create)
+		cartRouter.PATCH("/carts/:id", handler.Check)
+		cartRouter.DELETE("/carts/:id", handler.Delete)
+	}
+}
+
+func (handler *CartHandler) FindAll(ctx *gin.Context) {
+	user := utils.GetCurrentUser(ctx)
+
+	data := handler.usecase.FindAll(user.ID)
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *CartHandler) Create(ctx *gin.Context) {
+	var input dto.CartRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.Create(user.ID, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Response(
+		http.StatusCreated,
+		http.StatusText(http.StatusCreated),
+		data,
+	))
+}
+
+func (handler *CartHandler) Check(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input dto.CartCheckRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.Check(id, user.ID, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *CartHandler) Delete(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	user := utils.GetCurrentUser(ctx)
+
+	err := handler.usecase.Delete(id, user.ID)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		"ok",
+	))
+}
+
+func (handler *CartHandler) FindAllGuest(ctx *gin.Context) {
+	data := handler.usecase.FindAllGuest(utils.GetGuestCart(ctx))
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *CartHandler) CreateGuest(ctx *gin.Context) {
+	var input dto.CartRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	data, err := handler.usecase.CreateGuest(utils.GetGuestCart(ctx), input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	utils.SetGuestCart(ctx, data.GuestCart)
+
+	ctx.JSON(http.StatusCreated, response.Response(
+		http.StatusCreated,
+		http.StatusText(http.StatusCreated),
+		data,
+	))
+}
+
+func (handler *CartHandler) CheckGuest(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input dto.CartCheckRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	data, err := handler.usecase.CheckGuest(id, utils.GetGuestCart(ctx), input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *CartHandler) DeleteGuest(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	err := handler.usecase.DeleteGuest(id, utils.GetGuestCart(ctx))
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		"ok",
+	))
+}
@@ -0,0 +1,9 @@
+package cart
+
+type CartRequestBody struct {
+	ProductID int64 `json:"product_id" binding:"required"`
+}
+
+type CartCheckRequestBody struct {
+	IsChecked *bool `json:"is_checked" binding:"required"`
+}
@@ -0,0 +1,25 @@
+package cart
+
+import entity "e-course-management/internal/cart/entity"
+
+// CartResponse lists the cart of a user. Subtotal is the price of the checked
+// courses, the ones that would be bought on checkout.
+type CartResponse struct {
+	Carts        []entity.Cart `json:"carts"`
+	CheckedCount int           `json:"checked_count"`
+	Subtotal     int64         `json:"subtotal"`
+}
+
+// GuestCartResponse is the CartResponse of a visitor. GuestCart is the token
+// identifying the cart, empty until the first course is added.
+type GuestCartResponse struct {
+	GuestCart    string             `json:"guest_cart"`
+	Carts        []entity.GuestCart `json:"carts"`
+	CheckedCount int                `json:"checked_count"`
+	Subtotal     int64              `json:"subtotal"`
+}
+
+type GuestCartCreateResponse struct {
+	GuestCart string           `json:"guest_cart"`
+	Cart      entity.GuestCart `json:"cart"`
+}
@@ -0,0 +1,24 @@
+package cart
+
+import (
+	product "e-course-management/internal/product/entity"
+	user "e-course-management/internal/user/entity"
+	"time"
+)
+
+// Cart is one course in the cart of a user. A course is bought once, so
+// Quantity is always 1. Rows are removed for good rather than soft deleted
+// so that the (user_id, product_id) unique key keeps out duplicates.
+type Cart struct {
+	ID          int64            `json:"id"`
+	UserID      *int64           `json:"user_id"`
+	User        *user.User       `json:"-" gorm:"foreignKey:UserID;references:ID"`
+	ProductID   *int64           `json:"product_id"`
+	Product     *product.Product `json:"product" gorm:"foreignKey:ProductID;references:ID"`
+	Quantity    int              `json:"quantity"`
+	IsChecked   bool             `json:"is_checked"`
+	CreatedByID *int64           `json:"created_by" gorm:"column:created_by"`
+	UpdatedByID *int64           `json:"updated_by" gorm:"column:updated_by"`
+	CreatedAt   *time.Time       `json:"created_at"`
+	UpdatedAt   *time.Time       `json:"updated_at"`
+}
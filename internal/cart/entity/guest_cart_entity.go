@@ -0,0 +1,19 @@
+package cart
+
+import (
+	product "e-course-management/internal/product/entity"
+	"time"
+)
+
+// GuestCart is one course in the cart of a visitor who has not signed in yet.
+// GuestID comes from the signed guest cart token and the rows are moved to
+// carts when the visitor logs in or registers.
+type GuestCart struct {
+	ID        int64            `json:"id"`
+	GuestID   string           `json:"-"`
+	ProductID int64            `json:"product_id"`
+	Product   *product.Product `json:"product" gorm:"foreignKey:ProductID;references:ID"`
+	IsChecked bool             `json:"is_checked"`
+	CreatedAt *time.Time       `json:"created_at"`
+	UpdatedAt *time.Time       `json:"updated_at"`
+}
@@ -0,0 +1,37 @@
+//go:build wireinject
+// +build wireinject
+
+package cart
+
+import (
+	handler "e-course-management/internal/cart/delivery/http"
+	repository "e-course-management/internal/cart/repository"
+	usecase "e-course-management/internal/cart/usecase"
+	mediaRepository "e-course-management/internal/media/repository"
+	mediaUseCase "e-course-management/internal/media/usecase"
+	productRepository "e-course-management/internal/product/repository"
+	productUseCase "e-course-management/internal/product/usecase"
+	productCategoryRepository "e-course-management/internal/product_category/repository"
+	productCategoryUseCase "e-course-management/internal/product_category/usecase"
+	"e-course-management/pkg/storage"
+
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+func InitializedService(db *gorm.DB) *handler.CartHandler {
+	wire.Build(
+		handler.NewCartHandler,
+		usecase.NewCartUseCase,
+		repository.NewCartRepository,
+		productUseCase.NewProductUseCase,
+		productRepository.NewProductRepository,
+		productCategoryUseCase.NewProductCategoryUseCase,
+		productCategoryRepository.NewProductCategoryRepository,
+		mediaUseCase.NewMediaUseCase,
+		mediaRepository.NewMediaRepository,
+		storage.NewStorage,
+	)
+
+	return &handler.CartHandler{}
+}
@@ -0,0 +1,265 @@
+package cart
+
+import (
+	"errors"
+
+	entity "e-course-management/internal/cart/entity"
+	classRoomEntity "e-course-management/internal/class_room/entity"
+	"e-course-management/pkg/response"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CartRepository interface {
+	FindAllByUserId(userID int64) []entity.Cart
+	FindCheckedByUserId(userID int64) []entity.Cart
+	FindOneById(id int) (*entity.Cart, *response.Error)
+	IsOwned(userID int64, productID int64) bool
+	Create(cart entity.Cart) (*entity.Cart, *response.Error)
+	Update(cart entity.Cart) (*entity.Cart, *response.Error)
+	Delete(cart entity.Cart) *response.Error
+	DeleteByIds(ids []int64) *response.Error
+	FindAllByGuestId(guestID string) []entity.GuestCart
+	FindGuestById(id int) (*entity.GuestCart, *response.Error)
+	CreateGuest(guestCart entity.GuestCart) (*entity.GuestCart, *response.Error)
+	UpdateGuest(guestCart entity.GuestCart) (*entity.GuestCart, *response.Error)
+	DeleteGuest(guestCart entity.GuestCart) *response.Error
+	DeleteAllByGuestId(guestID string) *response.Error
+	WithTx(tx *gorm.DB) CartRepository
+}
+
+type cartRepository struct {
+	db *gorm.DB
+}
+
+// Create implements CartRepository.
+// Adding a course that is already in the cart is a conflict.
+func (repository *cartRepository) Create(cart entity.Cart) (*entity.Cart, *response.Error) {
+	result := repository.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&cart)
+
+	if result.Error != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  result.Error,
+		}
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, &response.Error{
+			Code: 409,
+			Err:  errors.New("this course is already in your cart"),
+		}
+	}
+
+	return &cart, nil
+}
+
+// CreateGuest implements CartRepository.
+func (repository *cartRepository) CreateGuest(guestCart entity.GuestCart) (*entity.GuestCart, *response.Error) {
+	result := repository.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&guestCart)
+
+	if result.Error != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  result.Error,
+		}
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, &response.Error{
+			Code: 409,
+			Err:  errors.New("this course is already in your cart"),
+		}
+	}
+
+	return &guestCart, nil
+}
+
+// Delete implements CartRepository.
+func (repository *cartRepository) Delete(cart entity.Cart) *response.Error {
+	if err := repository.db.Delete(&cart).Error; err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// DeleteAllByGuestId implements CartRepository.
+func (repository *cartRepository) DeleteAllByGuestId(guestID string) *response.Error {
+	if err := repository.db.Where("guest_id = ?", guestID).Delete(&entity.GuestCart{}).Error; err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// DeleteByIds implements CartRepository.
+func (repository *cartRepository) DeleteByIds(ids []int64) *response.Error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := repository.db.Where("id IN ?", ids).Delete(&entity.Cart{}).Error; err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// DeleteGuest implements CartRepository.
+func (repository *cartRepository) DeleteGuest(guestCart entity.GuestCart) *response.Error {
+	if err := repository.db.Delete(&guestCart).Error; err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// FindAllByGuestId implements CartRepository.
+// Courses that have been removed from the catalog are left out.
+func (repository *cartRepository) FindAllByGuestId(guestID string) []entity.GuestCart {
+	var guestCarts []entity.GuestCart
+
+	repository.db.
+		Preload("Product").
+		Joins("JOIN products ON products.id = guest_carts.product_id AND products.deleted_at IS NULL").
+		Where("guest_carts.guest_id = ?", guestID).
+		Order("guest_carts.id DESC").
+		Find(&guestCarts)
+
+	return guestCarts
+}
+
+// FindAllByUserId implements CartRepository.
+// Courses that have been removed from the catalog are left out.
+func (repository *cartRepository) FindAllByUserId(userID int64) []entity.Cart {
+	var carts []entity.Cart
+
+	repository.db.
+		Preload("Product").
+		Joins("JOIN products ON products.id = carts.product_id AND products.deleted_at IS NULL").
+		Where("carts.user_id = ?", userID).
+		Order("carts.id DESC").
+		Find(&carts)
+
+	return carts
+}
+
+// FindOneById implements CartRepository.
+func (repository *cartRepository) FindOneById(id int) (*entity.Cart, *response.Error) {
+	var cart entity.Cart
+
+	if err := repository.db.Preload("Product").First(&cart, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("cart not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &cart, nil
+}
+
+// FindCheckedByUserId implements CartRepository.
+// The rows are locked so that two checkouts can not buy the same cart.
+func (repository *cartRepository) FindCheckedByUserId(userID int64) []entity.Cart {
+	var carts []entity.Cart
+
+	repository.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Product").
+		Joins("JOIN products ON products.id = carts.product_id AND products.deleted_at IS NULL").
+		Where("carts.user_id = ? AND carts.is_checked = ?", userID, true).
+		Order("carts.id").
+		Find(&carts)
+
+	return carts
+}
+
+// FindGuestById implements CartRepository.
+func (repository *cartRepository) FindGuestById(id int) (*entity.GuestCart, *response.Error) {
+	var guestCart entity.GuestCart
+
+	if err := repository.db.Preload("Product").First(&guestCart, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("cart not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &guestCart, nil
+}
+
+// IsOwned implements CartRepository.
+func (repository *cartRepository) IsOwned(userID int64, productID int64) bool {
+	var count int64
+
+	repository.db.Model(&classRoomEntity.ClassRoom{}).
+		Where("user_id = ? AND product_id = ?", userID, productID).
+		Count(&count)
+
+	return count > 0
+}
+
+// Update implements CartRepository.
+func (repository *cartRepository) Update(cart entity.Cart) (*entity.Cart, *response.Error) {
+	cart.Product = nil
+
+	if err := repository.db.Save(&cart).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &cart, nil
+}
+
+// UpdateGuest implements CartRepository.
+func (repository *cartRepository) UpdateGuest(guestCart entity.GuestCart) (*entity.GuestCart, *response.Error) {
+	guestCart.Product = nil
+
+	if err := repository.db.Save(&guestCart).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &guestCart, nil
+}
+
+// WithTx implements CartRepository.
+func (repository *cartRepository) WithTx(tx *gorm.DB) CartRepository {
+	return &cartRepository{tx}
+}
+
+func NewCartRepository(db *gorm.DB) CartRepository {
+	return &cartRepository{db}
+}
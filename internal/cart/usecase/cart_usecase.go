@@ -0,0 +1,375 @@
+package cart
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strings"
+
+	dto "e-course-management/internal/cart/dto"
+	entity "e-course-management/internal/cart/entity"
+	repository "e-course-management/internal/cart/repository"
+	productUseCase "e-course-management/internal/product/usecase"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+type CartUseCase interface {
+	FindAll(userID int64) dto.CartResponse
+	Create(userID int64, request dto.CartRequestBody) (*entity.Cart, *response.Error)
+	Check(id int, userID int64, request dto.CartCheckRequestBody) (*entity.Cart, *response.Error)
+	Delete(id int, userID int64) *response.Error
+	FindAllGuest(token string) dto.GuestCartResponse
+	CreateGuest(token string, request dto.CartRequestBody) (*dto.GuestCartCreateResponse, *response.Error)
+	CheckGuest(id int, token string, request dto.CartCheckRequestBody) (*entity.GuestCart, *response.Error)
+	DeleteGuest(id int, token string) *response.Error
+	MergeGuest(token string, userID int64) *response.Error
+	FindChecked(userID int64) []entity.Cart
+	IsOwned(userID int64, productID int64) bool
+	DeleteAll(carts []entity.Cart) *response.Error
+	WithTx(tx *gorm.DB) CartUseCase
+}
+
+type cartUseCase struct {
+	db             *gorm.DB
+	repository     repository.CartRepository
+	productUseCase productUseCase.ProductUseCase
+}
+
+// Check implements CartUseCase.
+// Only checked courses are bought on checkout.
+func (usecase *cartUseCase) Check(id int, userID int64, request dto.CartCheckRequestBody) (*entity.Cart, *response.Error) {
+	cart, err := usecase.findOwn(id, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	product := cart.Product
+
+	cart.IsChecked = *request.IsChecked
+	cart.UpdatedByID = &userID
+
+	updated, err := usecase.repository.Update(*cart)
+
+	if err != nil {
+		return nil, err
+	}
+
+	updated.Product = product
+
+	return updated, nil
+}
+
+// CheckGuest implements CartUseCase.
+func (usecase *cartUseCase) CheckGuest(id int, token string, request dto.CartCheckRequestBody) (*entity.GuestCart, *response.Error) {
+	guestCart, err := usecase.findOwnGuest(id, token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	product := guestCart.Product
+
+	guestCart.IsChecked = *request.IsChecked
+
+	updated, err := usecase.repository.UpdateGuest(*guestCart)
+
+	if err != nil {
+		return nil, err
+	}
+
+	updated.Product = product
+
+	return updated, nil
+}
+
+// Create implements CartUseCase.
+// A course can not be added twice, nor when the user already owns it.
+func (usecase *cartUseCase) Create(userID int64, request dto.CartRequestBody) (*entity.Cart, *response.Error) {
+	product, err := usecase.productUseCase.FindOneById(int(request.ProductID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if usecase.repository.IsOwned(userID, product.ID) {
+		return nil, &response.Error{
+			Code: 409,
+			Err:  errors.New("you already own this course"),
+		}
+	}
+
+	cart, err := usecase.repository.Create(entity.Cart{
+		UserID:      &userID,
+		ProductID:   &product.ID,
+		Quantity:    1,
+		IsChecked:   true,
+		CreatedByID: &userID,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	cart.Product = product
+
+	return cart, nil
+}
+
+// CreateGuest implements CartUseCase.
+// A new guest cart token is issued when the given one is missing or invalid.
+func (usecase *cartUseCase) CreateGuest(token string, request dto.CartRequestBody) (*dto.GuestCartCreateResponse, *response.Error) {
+	product, err := usecase.productUseCase.FindOneById(int(request.ProductID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	guestID, ok := parseGuestToken(token)
+
+	if !ok {
+		guestID = utils.RandString(32)
+		token = signGuestID(guestID)
+	}
+
+	guestCart, err := usecase.repository.CreateGuest(entity.GuestCart{
+		GuestID:   guestID,
+		ProductID: product.ID,
+		IsChecked: true,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	guestCart.Product = product
+
+	return &dto.GuestCartCreateResponse{
+		GuestCart: token,
+		Cart:      *guestCart,
+	}, nil
+}
+
+// Delete implements CartUseCase.
+func (usecase *cartUseCase) Delete(id int, userID int64) *response.Error {
+	cart, err := usecase.findOwn(id, userID)
+
+	if err != nil {
+		return err
+	}
+
+	return usecase.repository.Delete(*cart)
+}
+
+// DeleteGuest implements CartUseCase.
+func (usecase *cartUseCase) DeleteGuest(id int, token string) *response.Error {
+	guestCart, err := usecase.findOwnGuest(id, token)
+
+	if err != nil {
+		return err
+	}
+
+	return usecase.repository.DeleteGuest(*guestCart)
+}
+
+// DeleteAll implements CartUseCase.
+func (usecase *cartUseCase) DeleteAll(carts []entity.Cart) *response.Error {
+	ids := make([]int64, 0, len(carts))
+
+	for _, cart := range carts {
+		ids = append(ids, cart.ID)
+	}
+
+	return usecase.repository.DeleteByIds(ids)
+}
+
+// FindAll implements CartUseCase.
+func (usecase *cartUseCase) FindAll(userID int64) dto.CartResponse {
+	result := dto.CartResponse{
+		Carts: usecase.repository.FindAllByUserId(userID),
+	}
+
+	if result.Carts == nil {
+		result.Carts = []entity.Cart{}
+	}
+
+	for _, cart := range result.Carts {
+		if cart.IsChecked {
+			result.CheckedCount++
+			result.Subtotal += cart.Product.Price
+		}
+	}
+
+	return result
+}
+
+// FindChecked implements CartUseCase.
+// The rows stay locked until the end of the transaction, see WithTx.
+func (usecase *cartUseCase) FindChecked(userID int64) []entity.Cart {
+	return usecase.repository.FindCheckedByUserId(userID)
+}
+
+// FindAllGuest implements CartUseCase.
+// An invalid token reads as an empty cart.
+func (usecase *cartUseCase) FindAllGuest(token string) dto.GuestCartResponse {
+	result := dto.GuestCartResponse{
+		Carts: []entity.GuestCart{},
+	}
+
+	guestID, ok := parseGuestToken(token)
+
+	if !ok {
+		return result
+	}
+
+	result.GuestCart = token
+
+	if guestCarts := usecase.repository.FindAllByGuestId(guestID); guestCarts != nil {
+		result.Carts = guestCarts
+	}
+
+	for _, guestCart := range result.Carts {
+		if guestCart.IsChecked {
+			result.CheckedCount++
+			result.Subtotal += guestCart.Product.Price
+		}
+	}
+
+	return result
+}
+
+// IsOwned implements CartUseCase.
+func (usecase *cartUseCase) IsOwned(userID int64, productID int64) bool {
+	return usecase.repository.IsOwned(userID, productID)
+}
+
+// MergeGuest implements CartUseCase.
+// The guest cart is moved into the cart of the user, skipping courses that
+// are already in it or that the user owns, and then emptied.
+func (usecase *cartUseCase) MergeGuest(token string, userID int64) *response.Error {
+	guestID, ok := parseGuestToken(token)
+
+	if !ok {
+		return nil
+	}
+
+	errTx := usecase.db.Transaction(func(tx *gorm.DB) error {
+		txRepository := usecase.repository.WithTx(tx)
+
+		for _, guestCart := range txRepository.FindAllByGuestId(guestID) {
+			if txRepository.IsOwned(userID, guestCart.ProductID) {
+				continue
+			}
+
+			productID := guestCart.ProductID
+
+			_, err := txRepository.Create(entity.Cart{
+				UserID:      &userID,
+				ProductID:   &productID,
+				Quantity:    1,
+				IsChecked:   guestCart.IsChecked,
+				CreatedByID: &userID,
+			})
+
+			// 409 means the course is already in the cart of the user
+			if err != nil && err.Code != 409 {
+				return err
+			}
+		}
+
+		if err := txRepository.DeleteAllByGuestId(guestID); err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	return response.FromError(errTx)
+}
+
+// WithTx implements CartUseCase.
+func (usecase *cartUseCase) WithTx(tx *gorm.DB) CartUseCase {
+	return &cartUseCase{tx, usecase.repository.WithTx(tx), usecase.productUseCase}
+}
+
+// findOwn returns the cart row, hiding rows of other users behind a 404
+func (usecase *cartUseCase) findOwn(id int, userID int64) (*entity.Cart, *response.Error) {
+	cart, err := usecase.repository.FindOneById(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if cart.UserID == nil || *cart.UserID != userID {
+		return nil, &response.Error{
+			Code: 404,
+			Err:  errors.New("cart not found"),
+		}
+	}
+
+	return cart, nil
+}
+
+// findOwnGuest returns the guest cart row, hiding rows of other guests
+// behind a 404
+func (usecase *cartUseCase) findOwnGuest(id int, token string) (*entity.GuestCart, *response.Error) {
+	guestID, ok := parseGuestToken(token)
+
+	if !ok {
+		return nil, &response.Error{
+			Code: 404,
+			Err:  errors.New("cart not found"),
+		}
+	}
+
+	guestCart, err := usecase.repository.FindGuestById(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if guestCart.GuestID != guestID {
+		return nil, &response.Error{
+			Code: 404,
+			Err:  errors.New("cart not found"),
+		}
+	}
+
+	return guestCart, nil
+}
+
+// signGuestID builds the guest cart token, the guest id followed by its
+// HMAC so that visitors can not pick the cart of someone else
+func signGuestID(guestID string) string {
+	mac := hmac.New(sha256.New, []byte(os.Getenv("JWT_SECRET")))
+	mac.Write([]byte(guestID))
+
+	return guestID + "." + hex.EncodeToString(mac.Sum(nil))
+}
+
+func parseGuestToken(token string) (string, bool) {
+	guestID, _, found := strings.Cut(token, ".")
+
+	if !found || guestID == "" {
+		return "", false
+	}
+
+	if !hmac.Equal([]byte(token), []byte(signGuestID(guestID))) {
+		return "", false
+	}
+
+	return guestID, true
+}
+
+func NewCartUseCase(
+	db *gorm.DB,
+	repository repository.CartRepository,
+	productUseCase productUseCase.ProductUseCase,
+) CartUseCase {
+	return &cartUseCase{db, repository, productUseCase}
+}
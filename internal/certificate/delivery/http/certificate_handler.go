@@ -0,0 +1,89 @@
+package certificate
+
+import (
+	"net/http"
+	"strconv"
+
+	usecase "e-course-management/internal/certificate/usecase"
+	"e-course-management/internal/middleware"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CertificateHandler struct {
+	usecase usecase.CertificateUseCase
+}
+
+func NewCertificateHandler(usecase usecase.CertificateUseCase) *CertificateHandler {
+	return &CertificateHandler{usecase}
+}
+
+func (handler *CertificateHandler) Route(r *gin.RouterGroup) {
+	certificateRouter := r.Group("/api/v1")
+
+	certificateRouter.GET("/certificates/:serial", handler.Verify)
+
+	certificateRouter.Use(middleware.AuthJwt, middleware.AuthUser)
+	{
+		certificateRouter.GET("/certificates", handler.FindAll)
+		certificateRouter.GET("/products/:id/certificate", handler.FindByProduct)
+	}
+}
+
+func (handler *CertificateHandler) Verify(ctx *gin.Context) {
+	data, err := handler.usecase.Verify(ctx.Param("serial"))
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *CertificateHandler) FindAll(ctx *gin.Context) {
+	user := utils.GetCurrentUser(ctx)
+
+	data := handler.usecase.FindAll(user.ID)
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *CertificateHandler) FindByProduct(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.FindByProduct(id, user.ID)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
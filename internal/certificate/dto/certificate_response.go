@@ -0,0 +1,24 @@
+package certificate
+
+import (
+	"time"
+
+	entity "e-course-management/internal/certificate/entity"
+	mediaDto "e-course-management/internal/media/dto"
+)
+
+type CertificateResponse struct {
+	Certificate entity.Certificate          `json:"certificate"`
+	Download    *mediaDto.SignedURLResponse `json:"download"`
+}
+
+// CertificateVerificationResponse is shown publicly, so it leaves out
+// anything that identifies the account
+type CertificateVerificationResponse struct {
+	Serial      string     `json:"serial"`
+	UserName    string     `json:"user_name"`
+	CourseTitle string     `json:"course_title"`
+	CompletedAt time.Time  `json:"completed_at"`
+	IssuedAt    *time.Time `json:"issued_at"`
+	IsValid     bool       `json:"is_valid"`
+}
@@ -0,0 +1,20 @@
+package certificate
+
+import "time"
+
+// Certificate keeps a copy of the user name and course title as they were at
+// completion, so later edits do not change an issued certificate. File is
+// the storage key of the PDF, rendered on first download.
+type Certificate struct {
+	ID          int64      `json:"id"`
+	Serial      string     `json:"serial"`
+	UserID      int64      `json:"user_id"`
+	ClassRoomID int64      `json:"class_room_id"`
+	ProductID   int64      `json:"product_id"`
+	UserName    string     `json:"user_name"`
+	CourseTitle string     `json:"course_title"`
+	CompletedAt time.Time  `json:"completed_at"`
+	File        *string    `json:"-"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
@@ -0,0 +1,29 @@
+//go:build wireinject
+// +build wireinject
+
+package certificate
+
+import (
+	handler "e-course-management/internal/certificate/delivery/http"
+	repository "e-course-management/internal/certificate/repository"
+	usecase "e-course-management/internal/certificate/usecase"
+	mediaRepository "e-course-management/internal/media/repository"
+	mediaUseCase "e-course-management/internal/media/usecase"
+	"e-course-management/pkg/storage"
+
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+func InitializedService(db *gorm.DB) *handler.CertificateHandler {
+	wire.Build(
+		handler.NewCertificateHandler,
+		usecase.NewCertificateUseCase,
+		repository.NewCertificateRepository,
+		mediaUseCase.NewMediaUseCase,
+		mediaRepository.NewMediaRepository,
+		storage.NewStorage,
+	)
+
+	return &handler.CertificateHandler{}
+}
@@ -0,0 +1,107 @@
+package certificate
+
+import (
+	"errors"
+
+	entity "e-course-management/internal/certificate/entity"
+	"e-course-management/pkg/response"
+
+	"gorm.io/gorm"
+)
+
+type CertificateRepository interface {
+	FindAllByUserId(userID int64) []entity.Certificate
+	FindOneBySerial(serial string) (*entity.Certificate, *response.Error)
+	FindOneByUserAndProduct(userID int64, productID int64) (*entity.Certificate, *response.Error)
+	FindOneByClassRoomId(classRoomID int64) *entity.Certificate
+	Create(certificate entity.Certificate) (*entity.Certificate, *response.Error)
+	UpdateFile(id int64, file string) *response.Error
+	WithTx(tx *gorm.DB) CertificateRepository
+}
+
+type certificateRepository struct {
+	db *gorm.DB
+}
+
+// Create implements CertificateRepository.
+func (repository *certificateRepository) Create(certificate entity.Certificate) (*entity.Certificate, *response.Error) {
+	if err := repository.db.Create(&certificate).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &certificate, nil
+}
+
+// FindAllByUserId implements CertificateRepository.
+func (repository *certificateRepository) FindAllByUserId(userID int64) []entity.Certificate {
+	var certificates []entity.Certificate
+
+	repository.db.Where("user_id = ?", userID).Order("completed_at DESC").Find(&certificates)
+
+	return certificates
+}
+
+// FindOneByClassRoomId implements CertificateRepository.
+func (repository *certificateRepository) FindOneByClassRoomId(classRoomID int64) *entity.Certificate {
+	var certificate entity.Certificate
+
+	if err := repository.db.Where("class_room_id = ?", classRoomID).First(&certificate).Error; err != nil {
+		return nil
+	}
+
+	return &certificate
+}
+
+// FindOneBySerial implements CertificateRepository.
+func (repository *certificateRepository) FindOneBySerial(serial string) (*entity.Certificate, *response.Error) {
+	return repository.findOne(repository.db.Where("serial = ?", serial))
+}
+
+// FindOneByUserAndProduct implements CertificateRepository.
+func (repository *certificateRepository) FindOneByUserAndProduct(userID int64, productID int64) (*entity.Certificate, *response.Error) {
+	return repository.findOne(repository.db.Where("user_id = ? AND product_id = ?", userID, productID))
+}
+
+// UpdateFile implements CertificateRepository.
+func (repository *certificateRepository) UpdateFile(id int64, file string) *response.Error {
+	if err := repository.db.Model(&entity.Certificate{}).Where("id = ?", id).Update("file", file).Error; err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// WithTx implements CertificateRepository.
+func (repository *certificateRepository) WithTx(tx *gorm.DB) CertificateRepository {
+	return &certificateRepository{tx}
+}
+
+func (repository *certificateRepository) findOne(query *gorm.DB) (*entity.Certificate, *response.Error) {
+	var certificate entity.Certificate
+
+	if err := query.First(&certificate).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("certificate not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &certificate, nil
+}
+
+func NewCertificateRepository(db *gorm.DB) CertificateRepository {
+	return &certificateRepository{db}
+}
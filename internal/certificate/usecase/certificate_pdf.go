@@ -0,0 +1,54 @@
+package certificate
+
+import (
+	"os"
+	"strings"
+
+	entity "e-course-management/internal/certificate/entity"
+	"e-course-management/pkg/pdf"
+)
+
+// renderCertificate draws a landscape A4 certificate
+func renderCertificate(certificate entity.Certificate) []byte {
+	document := pdf.NewDocument(pdf.A4Height, pdf.A4Width)
+	width := document.Width()
+	height := document.Height()
+
+	document.SetFillColor(0.97, 0.96, 0.92)
+	document.FillRect(0, 0, width, height)
+
+	document.SetStrokeColor(0.55, 0.42, 0.13)
+	document.Rect(24, 24, width-48, height-48, 3)
+	document.Rect(34, 34, width-68, height-68, 1)
+
+	document.SetFillColor(0.55, 0.42, 0.13)
+	document.TextCenter(pdf.HelveticaBold, 34, height-130, "CERTIFICATE OF COMPLETION")
+
+	document.SetFillColor(0.2, 0.2, 0.2)
+	document.TextCenter(pdf.Helvetica, 15, height-185, "This certifies that")
+
+	nameSize := pdf.FitSize(pdf.HelveticaBold, 32, width-160, certificate.UserName)
+	document.SetFillColor(0, 0, 0)
+	document.TextCenter(pdf.HelveticaBold, nameSize, height-245, certificate.UserName)
+	document.Line(width/2-220, height-260, width/2+220, height-260, 0.8)
+
+	document.SetFillColor(0.2, 0.2, 0.2)
+	document.TextCenter(pdf.Helvetica, 15, height-300, "has successfully completed the course")
+
+	titleSize := pdf.FitSize(pdf.HelveticaBold, 24, width-160, certificate.CourseTitle)
+	document.SetFillColor(0, 0, 0)
+	document.TextCenter(pdf.HelveticaBold, titleSize, height-345, certificate.CourseTitle)
+
+	document.SetFillColor(0.2, 0.2, 0.2)
+	document.TextCenter(pdf.Helvetica, 13, height-395, "Completed on "+certificate.CompletedAt.Format("02 January 2006"))
+
+	document.SetFillColor(0.35, 0.35, 0.35)
+	document.TextCenter(pdf.Helvetica, 10, 80, "Certificate No. "+certificate.Serial)
+	document.TextCenter(pdf.HelveticaOblique, 9, 64, "Verify at "+verificationURL(certificate.Serial))
+
+	return document.Bytes()
+}
+
+func verificationURL(serial string) string {
+	return strings.TrimSuffix(os.Getenv("APP_URL"), "/") + "/api/v1/certificates/" + serial
+}
@@ -0,0 +1,176 @@
+package certificate
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"time"
+
+	dto "e-course-management/internal/certificate/dto"
+	entity "e-course-management/internal/certificate/entity"
+	repository "e-course-management/internal/certificate/repository"
+	classRoomEntity "e-course-management/internal/class_room/entity"
+	mediaUseCase "e-course-management/internal/media/usecase"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/storage"
+
+	"gorm.io/gorm"
+)
+
+// serialAlphabet leaves out characters that are easy to misread
+const (
+	serialAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	serialLength   = 10
+)
+
+type CertificateUseCase interface {
+	Issue(classRoom classRoomEntity.ClassRoom) (*entity.Certificate, *response.Error)
+	FindAll(userID int64) []entity.Certificate
+	FindByProduct(productID int, userID int64) (*dto.CertificateResponse, *response.Error)
+	Verify(serial string) (*dto.CertificateVerificationResponse, *response.Error)
+	WithTx(tx *gorm.DB) CertificateUseCase
+}
+
+type certificateUseCase struct {
+	repository   repository.CertificateRepository
+	mediaUseCase mediaUseCase.MediaUseCase
+	storage      storage.Storage
+}
+
+// FindAll implements CertificateUseCase.
+func (usecase *certificateUseCase) FindAll(userID int64) []entity.Certificate {
+	return usecase.repository.FindAllByUserId(userID)
+}
+
+// FindByProduct implements CertificateUseCase.
+// The PDF is rendered and stored the first time it is requested.
+func (usecase *certificateUseCase) FindByProduct(productID int, userID int64) (*dto.CertificateResponse, *response.Error) {
+	certificate, err := usecase.repository.FindOneByUserAndProduct(userID, int64(productID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if certificate.File == nil {
+		file := "certificates/" + certificate.Serial + ".pdf"
+		content := renderCertificate(*certificate)
+
+		if err := usecase.storage.Put(file, bytes.NewReader(content), int64(len(content)), "application/pdf"); err != nil {
+			return nil, &response.Error{
+				Code: 500,
+				Err:  err,
+			}
+		}
+
+		if err := usecase.repository.UpdateFile(certificate.ID, file); err != nil {
+			return nil, err
+		}
+
+		certificate.File = &file
+	}
+
+	download, err := usecase.mediaUseCase.SignedURL(*certificate.File)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.CertificateResponse{
+		Certificate: *certificate,
+		Download:    download,
+	}, nil
+}
+
+// Issue implements CertificateUseCase.
+// Issuing twice for the same class room returns the existing certificate.
+func (usecase *certificateUseCase) Issue(classRoom classRoomEntity.ClassRoom) (*entity.Certificate, *response.Error) {
+	if existing := usecase.repository.FindOneByClassRoomId(classRoom.ID); existing != nil {
+		return existing, nil
+	}
+
+	serial, errSerial := newSerial()
+
+	if errSerial != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  errSerial,
+		}
+	}
+
+	completedAt := time.Now()
+
+	if classRoom.CompletedAt != nil {
+		completedAt = *classRoom.CompletedAt
+	}
+
+	certificate := entity.Certificate{
+		Serial:      serial,
+		UserID:      *classRoom.UserID,
+		ClassRoomID: classRoom.ID,
+		ProductID:   *classRoom.ProductID,
+		CompletedAt: completedAt,
+	}
+
+	if classRoom.User != nil {
+		certificate.UserName = classRoom.User.Name
+	}
+
+	if classRoom.Product != nil {
+		certificate.CourseTitle = classRoom.Product.Title
+	}
+
+	return usecase.repository.Create(certificate)
+}
+
+// Verify implements CertificateUseCase.
+func (usecase *certificateUseCase) Verify(serial string) (*dto.CertificateVerificationResponse, *response.Error) {
+	certificate, err := usecase.repository.FindOneBySerial(serial)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.CertificateVerificationResponse{
+		Serial:      certificate.Serial,
+		UserName:    certificate.UserName,
+		CourseTitle: certificate.CourseTitle,
+		CompletedAt: certificate.CompletedAt,
+		IssuedAt:    certificate.CreatedAt,
+		IsValid:     true,
+	}, nil
+}
+
+// WithTx implements CertificateUseCase.
+func (usecase *certificateUseCase) WithTx(tx *gorm.DB) CertificateUseCase {
+	return &certificateUseCase{
+		usecase.repository.WithTx(tx),
+		usecase.mediaUseCase,
+		usecase.storage,
+	}
+}
+
+// newSerial returns e.g. EC-2026-K7QW3MZ9PA
+func newSerial() (string, error) {
+	serial := make([]byte, serialLength)
+	max := big.NewInt(int64(len(serialAlphabet)))
+
+	for i := range serial {
+		n, err := rand.Int(rand.Reader, max)
+
+		if err != nil {
+			return "", err
+		}
+
+		serial[i] = serialAlphabet[n.Int64()]
+	}
+
+	return "EC-" + time.Now().Format("2006") + "-" + string(serial), nil
+}
+
+func NewCertificateUseCase(
+	repository repository.CertificateRepository,
+	mediaUseCase mediaUseCase.MediaUseCase,
+	storage storage.Storage,
+) CertificateUseCase {
+	return &certificateUseCase{repository, mediaUseCase, storage}
+}
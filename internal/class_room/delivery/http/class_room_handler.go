@@ -0,0 +1,70 @@
+package class_room
+
+import (
+	"net/http"
+	"strconv"
+
+	usecase "e-course-management/internal/class_room/usecase"
+	"e-course-management/internal/middleware"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ClassRoomHandler struct {
+	usecase usecase.ClassRoomUseCase
+}
+
+func NewClassRoomHandler(usecase usecase.ClassRoomUseCase) *ClassRoomHandler {
+	return &ClassRoomHandler{usecase}
+}
+
+func (handler *ClassRoomHandler) Route(r *gin.RouterGroup) {
+	classRoomRouter := r.Group("/api/v1")
+
+	classRoomRouter.Use(middleware.AuthJwt, middleware.AuthUser)
+	{
+		classRoomRouter.GET("/class_rooms", handler.FindAllMine)
+		classRoomRouter.GET("/class_rooms/products/:product_id", handler.FindOneByProductId)
+	}
+}
+
+func (handler *ClassRoomHandler) FindAllMine(ctx *gin.Context) {
+	offset, _ := strconv.Atoi(ctx.Query("offset"))
+	limit, _ := strconv.Atoi(ctx.Query("limit"))
+
+	user := utils.GetCurrentUser(ctx)
+
+	data := handler.usecase.FindAllMine(user.ID, offset, limit)
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *ClassRoomHandler) FindOneByProductId(ctx *gin.Context) {
+	productID, _ := strconv.Atoi(ctx.Param("product_id"))
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.FindOneByProductId(user.ID, int64(productID))
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
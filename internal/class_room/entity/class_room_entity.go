@@ -0,0 +1,24 @@
+package class_room
+
+import (
+	product "e-course-management/internal/product/entity"
+	user "e-course-management/internal/user/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ClassRoom struct {
+	ID          int64            `json:"id"`
+	UserID      *int64           `json:"user_id"`
+	User        *user.User       `json:"-" gorm:"foreignKey:UserID;references:ID"`
+	Product     *product.Product `json:"product" gorm:"foreignKey:ProductID;references:ID"`
+	ProductID   *int64           `json:"product_id"`
+	Progress    int              `json:"progress"`
+	CompletedAt *time.Time       `json:"completed_at"`
+	CreatedByID *int64           `json:"created_by" gorm:"column:created_by"`
+	UpdatedByID *int64           `json:"updated_by" gorm:"column:updated_by"`
+	CreatedAt   *time.Time       `json:"created_at"`
+	UpdatedAt   *time.Time       `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt   `json:"deleted_at"`
+}
@@ -0,0 +1,23 @@
+//go:build wireinject
+// +build wireinject
+
+package class_room
+
+import (
+	handler "e-course-management/internal/class_room/delivery/http"
+	repository "e-course-management/internal/class_room/repository"
+	usecase "e-course-management/internal/class_room/usecase"
+
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+func InitializedService(db *gorm.DB) *handler.ClassRoomHandler {
+	wire.Build(
+		handler.NewClassRoomHandler,
+		usecase.NewClassRoomUseCase,
+		repository.NewClassRoomRepository,
+	)
+
+	return &handler.ClassRoomHandler{}
+}
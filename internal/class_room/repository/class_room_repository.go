@@ -0,0 +1,101 @@
+package class_room
+
+import (
+	"errors"
+
+	entity "e-course-management/internal/class_room/entity"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ClassRoomRepository interface {
+	FindAllByUserId(userID int64, offset int, limit int) []entity.ClassRoom
+	FindOneByUserIdAndProductId(userID int64, productID int64) (*entity.ClassRoom, *response.Error)
+	Create(classRooms []entity.ClassRoom) *response.Error
+	WithTx(tx *gorm.DB) ClassRoomRepository
+}
+
+type classRoomRepository struct {
+	db *gorm.DB
+}
+
+// Create implements ClassRoomRepository.
+// A user holds at most one class room per course, so enrolling again only
+// restores a class room that was deleted and keeps its progress.
+func (repository *classRoomRepository) Create(classRooms []entity.ClassRoom) *response.Error {
+	if len(classRooms) == 0 {
+		return nil
+	}
+
+	err := repository.db.
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{"deleted_at": nil}),
+		}).
+		Create(&classRooms).
+		Error
+
+	if err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// FindAllByUserId implements ClassRoomRepository.
+// Courses that were removed from the catalogue are left out.
+func (repository *classRoomRepository) FindAllByUserId(userID int64, offset int, limit int) []entity.ClassRoom {
+	var classRooms []entity.ClassRoom
+
+	repository.db.
+		Scopes(utils.Paginate(offset, limit)).
+		Preload("Product").
+		Joins("JOIN products ON products.id = class_rooms.product_id AND products.deleted_at IS NULL").
+		Where("class_rooms.user_id = ?", userID).
+		Order("class_rooms.created_at DESC, class_rooms.id DESC").
+		Find(&classRooms)
+
+	return classRooms
+}
+
+// FindOneByUserIdAndProductId implements ClassRoomRepository.
+func (repository *classRoomRepository) FindOneByUserIdAndProductId(userID int64, productID int64) (*entity.ClassRoom, *response.Error) {
+	var classRoom entity.ClassRoom
+
+	err := repository.db.
+		Preload("Product").
+		Where("user_id = ? AND product_id = ?", userID, productID).
+		First(&classRoom).
+		Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 403,
+				Err:  errors.New("you are not enrolled in this course"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &classRoom, nil
+}
+
+// WithTx implements ClassRoomRepository.
+func (repository *classRoomRepository) WithTx(tx *gorm.DB) ClassRoomRepository {
+	return &classRoomRepository{tx}
+}
+
+func NewClassRoomRepository(db *gorm.DB) ClassRoomRepository {
+	return &classRoomRepository{db}
+}
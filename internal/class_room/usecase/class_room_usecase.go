@@ -0,0 +1,73 @@
+package class_room
+
+import (
+	entity "e-course-management/internal/class_room/entity"
+	repository "e-course-management/internal/class_room/repository"
+	"e-course-management/pkg/response"
+
+	"gorm.io/gorm"
+)
+
+type ClassRoomUseCase interface {
+	FindAllMine(userID int64, offset int, limit int) []entity.ClassRoom
+	FindOneByProductId(userID int64, productID int64) (*entity.ClassRoom, *response.Error)
+	HasAccess(userID int64, productID int64) bool
+	Enroll(userID int64, productIDs []int64) *response.Error
+	WithTx(tx *gorm.DB) ClassRoomUseCase
+}
+
+type classRoomUseCase struct {
+	repository repository.ClassRoomRepository
+}
+
+// Enroll implements ClassRoomUseCase.
+// Enrolling is idempotent, so a paid order may be processed more than once.
+func (usecase *classRoomUseCase) Enroll(userID int64, productIDs []int64) *response.Error {
+	classRooms := make([]entity.ClassRoom, 0, len(productIDs))
+	seen := map[int64]bool{}
+
+	for _, productID := range productIDs {
+		if seen[productID] {
+			continue
+		}
+
+		seen[productID] = true
+
+		user, product := userID, productID
+
+		classRooms = append(classRooms, entity.ClassRoom{
+			UserID:      &user,
+			ProductID:   &product,
+			CreatedByID: &user,
+		})
+	}
+
+	return usecase.repository.Create(classRooms)
+}
+
+// FindAllMine implements ClassRoomUseCase.
+func (usecase *classRoomUseCase) FindAllMine(userID int64, offset int, limit int) []entity.ClassRoom {
+	return usecase.repository.FindAllByUserId(userID, offset, limit)
+}
+
+// FindOneByProductId implements ClassRoomUseCase.
+func (usecase *classRoomUseCase) FindOneByProductId(userID int64, productID int64) (*entity.ClassRoom, *response.Error) {
+	return usecase.repository.FindOneByUserIdAndProductId(userID, productID)
+}
+
+// HasAccess implements ClassRoomUseCase.
+// Lessons and videos of a course are only served to its students.
+func (usecase *classRoomUseCase) HasAccess(userID int64, productID int64) bool {
+	classRoom, _ := usecase.repository.FindOneByUserIdAndProductId(userID, productID)
+
+	return classRoom != nil
+}
+
+// WithTx implements ClassRoomUseCase.
+func (usecase *classRoomUseCase) WithTx(tx *gorm.DB) ClassRoomUseCase {
+	return &classRoomUseCase{usecase.repository.WithTx(tx)}
+}
+
+func NewClassRoomUseCase(repository repository.ClassRoomRepository) ClassRoomUseCase {
+	return &classRoomUseCase{repository}
+}
@@ -0,0 +1,355 @@
+package curriculum
+
+import (
+	"net/http"
+	"strconv"
+
+	dto "e-course-management/internal/curriculum/dto"
+	usecase "e-course-management/internal/curriculum/usecase"
+	"e-course-management/internal/middleware"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CurriculumHandler struct {
+	usecase usecase.CurriculumUseCase
+}
+
+func NewCurriculumHandler(usecase usecase.CurriculumUseCase) *CurriculumHandler {
+	return &CurriculumHandler{usecase}
+}
+
+func (handler *CurriculumHandler) Route(r *gin.RouterGroup) {
+	curriculumRouter := r.Group("/api/v1")
+
+	curriculumRouter.GET("/products/:id/curriculum", middleware.OptionalAuthJwt, handler.Outline)
+	curriculumRouter.GET("/lessons/:id", middleware.OptionalAuthJwt, handler.FindLessonById)
+
+	curriculumRouter.Use(middleware.AuthJwt, middleware.AuthAdmin)
+	{
+		curriculumRouter.POST("/products/:id/sections", handler.CreateSection)
+		curriculumRouter.PUT("/products/:id/sections/order", handler.ReorderSections)
+		curriculumRouter.PATCH("/sections/:id", handler.UpdateSection)
+		curriculumRouter.DELETE("/sections/:id", handler.DeleteSection)
+		curriculumRouter.POST("/sections/:id/lessons", handler.CreateLesson)
+		curriculumRouter.PUT("/sections/:id/lessons/order", handler.ReorderLessons)
+		curriculumRouter.PATCH("/lessons/:id", handler.UpdateLesson)
+		curriculumRouter.DELETE("/lessons/:id", handler.DeleteLesson)
+	}
+}
+
+func (handler *CurriculumHandler) Outline(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	data, err := handler.usecase.Outline(id, utils.GetOptionalUser(ctx))
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *CurriculumHandler) FindLessonById(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	data, err := handler.usecase.FindLessonById(id, utils.GetOptionalUser(ctx))
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *CurriculumHandler) CreateSection(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input dto.SectionRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+	input.CreatedBy = &user.ID
+
+	data, err := handler.usecase.CreateSection(id, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Response(
+		http.StatusCreated,
+		http.StatusText(http.StatusCreated),
+		data,
+	))
+}
+
+func (handler *CurriculumHandler) UpdateSection(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input dto.SectionRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+	input.UpdatedBy = &user.ID
+
+	data, err := handler.usecase.UpdateSection(id, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *CurriculumHandler) ReorderSections(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input dto.ReorderRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+	input.UpdatedBy = &user.ID
+
+	data, err := handler.usecase.ReorderSections(id, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *CurriculumHandler) CreateLesson(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input dto.LessonRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+	input.CreatedBy = &user.ID
+
+	data, err := handler.usecase.CreateLesson(id, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Response(
+		http.StatusCreated,
+		http.StatusText(http.StatusCreated),
+		data,
+	))
+}
+
+func (handler *CurriculumHandler) UpdateLesson(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input dto.LessonRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+	input.UpdatedBy = &user.ID
+
+	data, err := handler.usecase.UpdateLesson(id, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *CurriculumHandler) ReorderLessons(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input dto.ReorderRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+	input.UpdatedBy = &user.ID
+
+	data, err := handler.usecase.ReorderLessons(id, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *CurriculumHandler) DeleteSection(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	user := utils.GetCurrentUser(ctx)
+
+	err := handler.usecase.DeleteSection(id, &user.ID)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		"ok",
+	))
+}
+
+func (handler *CurriculumHandler) DeleteLesson(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	user := utils.GetCurrentUser(ctx)
+
+	err := handler.usecase.DeleteLesson(id, &user.ID)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		"ok",
+	))
+}
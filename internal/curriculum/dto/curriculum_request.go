@@ -0,0 +1,30 @@
+package curriculum
+
+type SectionRequestBody struct {
+	Title     string `json:"title" binding:"required"`
+	CreatedBy *int64 `json:"-"`
+	UpdatedBy *int64 `json:"-"`
+}
+
+type LessonRequestBody struct {
+	// SectionID moves the lesson to the end of another section of the same
+	// product, only used on update
+	SectionID     *int64  `json:"section_id"`
+	Title         string  `json:"title" binding:"required"`
+	Type          string  `json:"type" binding:"required,oneof=video article quiz attachment"`
+	Duration      int     `json:"duration" binding:"min=0"`
+	IsFreePreview bool    `json:"is_free_preview"`
+	IsOptional    bool    `json:"is_optional"`
+	Video         *string `json:"video"`
+	Content       *string `json:"content"`
+	Attachment    *string `json:"attachment"`
+	CreatedBy     *int64  `json:"-"`
+	UpdatedBy     *int64  `json:"-"`
+}
+
+// ReorderRequestBody lists every section of a product, or every lesson of a
+// section, in the new order
+type ReorderRequestBody struct {
+	IDs       []int64 `json:"ids" binding:"required,min=1"`
+	UpdatedBy *int64  `json:"-"`
+}
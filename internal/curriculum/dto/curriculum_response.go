@@ -0,0 +1,35 @@
+package curriculum
+
+import mediaDto "e-course-management/internal/media/dto"
+
+type CurriculumResponse struct {
+	ProductID     int64            `json:"product_id"`
+	IsEnrolled    bool             `json:"is_enrolled"`
+	TotalLessons  int              `json:"total_lessons"`
+	TotalDuration int              `json:"total_duration"`
+	Sections      []SectionOutline `json:"sections"`
+}
+
+type SectionOutline struct {
+	ID       int64           `json:"id"`
+	Title    string          `json:"title"`
+	Position int             `json:"position"`
+	Duration int             `json:"duration"`
+	Lessons  []LessonOutline `json:"lessons"`
+}
+
+// LessonOutline carries the lesson content only when IsLocked is false
+type LessonOutline struct {
+	ID            int64                       `json:"id"`
+	SectionID     int64                       `json:"section_id"`
+	Title         string                      `json:"title"`
+	Type          string                      `json:"type"`
+	Position      int                         `json:"position"`
+	Duration      int                         `json:"duration"`
+	IsFreePreview bool                        `json:"is_free_preview"`
+	IsOptional    bool                        `json:"is_optional"`
+	IsLocked      bool                        `json:"is_locked"`
+	Video         *mediaDto.SignedURLResponse `json:"video,omitempty"`
+	Content       *string                     `json:"content,omitempty"`
+	Attachment    *string                     `json:"attachment,omitempty"`
+}
@@ -0,0 +1,39 @@
+package curriculum
+
+import (
+	admin "e-course-management/internal/admin/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	LessonTypeVideo      = "video"
+	LessonTypeArticle    = "article"
+	LessonTypeQuiz       = "quiz"
+	LessonTypeAttachment = "attachment"
+)
+
+// Lesson durations are in seconds. Optional lessons do not count towards
+// course completion.
+type Lesson struct {
+	ID            int64          `json:"id"`
+	SectionID     int64          `json:"section_id"`
+	Section       *Section       `json:"-" gorm:"foreignKey:SectionID;references:ID"`
+	Title         string         `json:"title"`
+	Type          string         `json:"type"`
+	Position      int            `json:"position"`
+	Duration      int            `json:"duration"`
+	IsFreePreview bool           `json:"is_free_preview"`
+	IsOptional    bool           `json:"is_optional"`
+	Video         *string        `json:"video"`
+	Content       *string        `json:"content"`
+	Attachment    *string        `json:"attachment"`
+	CreatedByID   *int64         `json:"created_by" gorm:"column:created_by"`
+	CreatedBy     *admin.Admin   `json:"-" gorm:"foreignKey:CreatedByID;references:ID"`
+	UpdatedByID   *int64         `json:"updated_by" gorm:"column:updated_by"`
+	UpdatedBy     *admin.Admin   `json:"-" gorm:"foreignKey:UpdatedByID;references:ID"`
+	CreatedAt     *time.Time     `json:"created_at"`
+	UpdatedAt     *time.Time     `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"deleted_at"`
+}
@@ -0,0 +1,23 @@
+package curriculum
+
+import (
+	admin "e-course-management/internal/admin/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Section struct {
+	ID          int64          `json:"id"`
+	ProductID   int64          `json:"product_id"`
+	Title       string         `json:"title"`
+	Position    int            `json:"position"`
+	Lessons     []Lesson       `json:"lessons,omitempty" gorm:"foreignKey:SectionID;references:ID"`
+	CreatedByID *int64         `json:"created_by" gorm:"column:created_by"`
+	CreatedBy   *admin.Admin   `json:"-" gorm:"foreignKey:CreatedByID;references:ID"`
+	UpdatedByID *int64         `json:"updated_by" gorm:"column:updated_by"`
+	UpdatedBy   *admin.Admin   `json:"-" gorm:"foreignKey:UpdatedByID;references:ID"`
+	CreatedAt   *time.Time     `json:"created_at"`
+	UpdatedAt   *time.Time     `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at"`
+}
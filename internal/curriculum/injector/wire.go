@@ -0,0 +1,41 @@
+//go:build wireinject
+// +build wireinject
+
+package curriculum
+
+import (
+	classRoomRepository "e-course-management/internal/class_room/repository"
+	classRoomUseCase "e-course-management/internal/class_room/usecase"
+	handler "e-course-management/internal/curriculum/delivery/http"
+	repository "e-course-management/internal/curriculum/repository"
+	usecase "e-course-management/internal/curriculum/usecase"
+	mediaRepository "e-course-management/internal/media/repository"
+	mediaUseCase "e-course-management/internal/media/usecase"
+	productRepository "e-course-management/internal/product/repository"
+	productUseCase "e-course-management/internal/product/usecase"
+	productCategoryRepository "e-course-management/internal/product_category/repository"
+	productCategoryUseCase "e-course-management/internal/product_category/usecase"
+	"e-course-management/pkg/storage"
+
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+func InitializedService(db *gorm.DB) *handler.CurriculumHandler {
+	wire.Build(
+		handler.NewCurriculumHandler,
+		usecase.NewCurriculumUseCase,
+		repository.NewCurriculumRepository,
+		productUseCase.NewProductUseCase,
+		productRepository.NewProductRepository,
+		productCategoryUseCase.NewProductCategoryUseCase,
+		productCategoryRepository.NewProductCategoryRepository,
+		mediaUseCase.NewMediaUseCase,
+		mediaRepository.NewMediaRepository,
+		storage.NewStorage,
+		classRoomUseCase.NewClassRoomUseCase,
+		classRoomRepository.NewClassRoomRepository,
+	)
+
+	return &handler.CurriculumHandler{}
+}
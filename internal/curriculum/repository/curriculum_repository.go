@@ -0,0 +1,264 @@
+package curriculum
+
+import (
+	"errors"
+
+	entity "e-course-management/internal/curriculum/entity"
+	"e-course-management/pkg/response"
+
+	"gorm.io/gorm"
+)
+
+type CurriculumRepository interface {
+	FindSectionsByProductId(productID int64) []entity.Section
+	FindSectionById(id int) (*entity.Section, *response.Error)
+	CreateSection(section entity.Section) (*entity.Section, *response.Error)
+	UpdateSection(section entity.Section) (*entity.Section, *response.Error)
+	DeleteSection(section entity.Section) *response.Error
+	ReorderSections(ids []int64, updatedBy *int64) *response.Error
+	NextSectionPosition(productID int64) int
+	FindLessonsBySectionId(sectionID int64) []entity.Lesson
+	FindLessonById(id int) (*entity.Lesson, *response.Error)
+	CreateLesson(lesson entity.Lesson) (*entity.Lesson, *response.Error)
+	UpdateLesson(lesson entity.Lesson) (*entity.Lesson, *response.Error)
+	DeleteLesson(lesson entity.Lesson) *response.Error
+	ReorderLessons(ids []int64, updatedBy *int64) *response.Error
+	NextLessonPosition(sectionID int64) int
+}
+
+type curriculumRepository struct {
+	db *gorm.DB
+}
+
+// CreateLesson implements CurriculumRepository.
+func (repository *curriculumRepository) CreateLesson(lesson entity.Lesson) (*entity.Lesson, *response.Error) {
+	if err := repository.db.Create(&lesson).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &lesson, nil
+}
+
+// CreateSection implements CurriculumRepository.
+func (repository *curriculumRepository) CreateSection(section entity.Section) (*entity.Section, *response.Error) {
+	if err := repository.db.Create(&section).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &section, nil
+}
+
+// DeleteLesson implements CurriculumRepository.
+func (repository *curriculumRepository) DeleteLesson(lesson entity.Lesson) *response.Error {
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&lesson).Update("updated_by", lesson.UpdatedByID).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&lesson).Error
+	})
+
+	if err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// DeleteSection implements CurriculumRepository.
+// The lessons of the section are soft deleted along with it.
+func (repository *curriculumRepository) DeleteSection(section entity.Section) *response.Error {
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		lessons := tx.Model(&entity.Lesson{}).Where("section_id = ?", section.ID)
+
+		if err := lessons.Update("updated_by", section.UpdatedByID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("section_id = ?", section.ID).Delete(&entity.Lesson{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&section).Update("updated_by", section.UpdatedByID).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&section).Error
+	})
+
+	if err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// FindLessonById implements CurriculumRepository.
+func (repository *curriculumRepository) FindLessonById(id int) (*entity.Lesson, *response.Error) {
+	var lesson entity.Lesson
+
+	if err := repository.db.Preload("Section").First(&lesson, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("lesson not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &lesson, nil
+}
+
+// FindLessonsBySectionId implements CurriculumRepository.
+func (repository *curriculumRepository) FindLessonsBySectionId(sectionID int64) []entity.Lesson {
+	var lessons []entity.Lesson
+
+	repository.db.Where("section_id = ?", sectionID).Order("position, id").Find(&lessons)
+
+	return lessons
+}
+
+// FindSectionById implements CurriculumRepository.
+func (repository *curriculumRepository) FindSectionById(id int) (*entity.Section, *response.Error) {
+	var section entity.Section
+
+	if err := repository.db.First(&section, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("section not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &section, nil
+}
+
+// FindSectionsByProductId implements CurriculumRepository.
+// Sections come with their lessons, both in curriculum order.
+func (repository *curriculumRepository) FindSectionsByProductId(productID int64) []entity.Section {
+	var sections []entity.Section
+
+	repository.db.
+		Preload("Lessons", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position, id")
+		}).
+		Where("product_id = ?", productID).
+		Order("position, id").
+		Find(&sections)
+
+	return sections
+}
+
+// NextLessonPosition implements CurriculumRepository.
+func (repository *curriculumRepository) NextLessonPosition(sectionID int64) int {
+	var position int
+
+	repository.db.Model(&entity.Lesson{}).
+		Where("section_id = ?", sectionID).
+		Select("COALESCE(MAX(position), 0)").
+		Scan(&position)
+
+	return position + 1
+}
+
+// NextSectionPosition implements CurriculumRepository.
+func (repository *curriculumRepository) NextSectionPosition(productID int64) int {
+	var position int
+
+	repository.db.Model(&entity.Section{}).
+		Where("product_id = ?", productID).
+		Select("COALESCE(MAX(position), 0)").
+		Scan(&position)
+
+	return position + 1
+}
+
+// ReorderLessons implements CurriculumRepository.
+func (repository *curriculumRepository) ReorderLessons(ids []int64, updatedBy *int64) *response.Error {
+	return reorder(repository.db, &entity.Lesson{}, ids, updatedBy)
+}
+
+// ReorderSections implements CurriculumRepository.
+func (repository *curriculumRepository) ReorderSections(ids []int64, updatedBy *int64) *response.Error {
+	return reorder(repository.db, &entity.Section{}, ids, updatedBy)
+}
+
+// UpdateLesson implements CurriculumRepository.
+func (repository *curriculumRepository) UpdateLesson(lesson entity.Lesson) (*entity.Lesson, *response.Error) {
+	lesson.Section = nil
+
+	if err := repository.db.Save(&lesson).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &lesson, nil
+}
+
+// UpdateSection implements CurriculumRepository.
+func (repository *curriculumRepository) UpdateSection(section entity.Section) (*entity.Section, *response.Error) {
+	if err := repository.db.Omit("Lessons").Save(&section).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &section, nil
+}
+
+// reorder writes positions 1..n following the order of ids
+func reorder(db *gorm.DB, model interface{}, ids []int64, updatedBy *int64) *response.Error {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			err := tx.Model(model).
+				Where("id = ?", id).
+				Updates(map[string]interface{}{"position": i + 1, "updated_by": updatedBy}).
+				Error
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+func NewCurriculumRepository(db *gorm.DB) CurriculumRepository {
+	return &curriculumRepository{db}
+}
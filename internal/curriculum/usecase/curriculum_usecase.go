@@ -0,0 +1,394 @@
+package curriculum
+
+import (
+	"errors"
+
+	classRoomUseCase "e-course-management/internal/class_room/usecase"
+	dto "e-course-management/internal/curriculum/dto"
+	entity "e-course-management/internal/curriculum/entity"
+	repository "e-course-management/internal/curriculum/repository"
+	mediaUseCase "e-course-management/internal/media/usecase"
+	oauthDto "e-course-management/internal/oauth/dto"
+	productUseCase "e-course-management/internal/product/usecase"
+	"e-course-management/pkg/response"
+)
+
+type CurriculumUseCase interface {
+	Outline(productID int, user *oauthDto.ClaimsResponse) (*dto.CurriculumResponse, *response.Error)
+	FindLessonById(id int, user *oauthDto.ClaimsResponse) (*dto.LessonOutline, *response.Error)
+	CreateSection(productID int, dto dto.SectionRequestBody) (*entity.Section, *response.Error)
+	UpdateSection(id int, dto dto.SectionRequestBody) (*entity.Section, *response.Error)
+	DeleteSection(id int, deletedBy *int64) *response.Error
+	ReorderSections(productID int, dto dto.ReorderRequestBody) ([]entity.Section, *response.Error)
+	CreateLesson(sectionID int, dto dto.LessonRequestBody) (*entity.Lesson, *response.Error)
+	UpdateLesson(id int, dto dto.LessonRequestBody) (*entity.Lesson, *response.Error)
+	DeleteLesson(id int, deletedBy *int64) *response.Error
+	ReorderLessons(sectionID int, dto dto.ReorderRequestBody) ([]entity.Lesson, *response.Error)
+}
+
+type curriculumUseCase struct {
+	repository       repository.CurriculumRepository
+	productUseCase   productUseCase.ProductUseCase
+	mediaUseCase     mediaUseCase.MediaUseCase
+	classRoomUseCase classRoomUseCase.ClassRoomUseCase
+}
+
+// CreateLesson implements CurriculumUseCase.
+func (usecase *curriculumUseCase) CreateLesson(sectionID int, dto dto.LessonRequestBody) (*entity.Lesson, *response.Error) {
+	section, err := usecase.repository.FindSectionById(sectionID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateLesson(dto); err != nil {
+		return nil, err
+	}
+
+	lesson := entity.Lesson{
+		SectionID:     section.ID,
+		Title:         dto.Title,
+		Type:          dto.Type,
+		Position:      usecase.repository.NextLessonPosition(section.ID),
+		Duration:      dto.Duration,
+		IsFreePreview: dto.IsFreePreview,
+		IsOptional:    dto.IsOptional,
+		Video:         dto.Video,
+		Content:       dto.Content,
+		Attachment:    dto.Attachment,
+		CreatedByID:   dto.CreatedBy,
+	}
+
+	return usecase.repository.CreateLesson(lesson)
+}
+
+// CreateSection implements CurriculumUseCase.
+func (usecase *curriculumUseCase) CreateSection(productID int, dto dto.SectionRequestBody) (*entity.Section, *response.Error) {
+	product, err := usecase.productUseCase.FindOneById(productID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	section := entity.Section{
+		ProductID:   product.ID,
+		Title:       dto.Title,
+		Position:    usecase.repository.NextSectionPosition(product.ID),
+		CreatedByID: dto.CreatedBy,
+	}
+
+	return usecase.repository.CreateSection(section)
+}
+
+// DeleteLesson implements CurriculumUseCase.
+func (usecase *curriculumUseCase) DeleteLesson(id int, deletedBy *int64) *response.Error {
+	lesson, err := usecase.repository.FindLessonById(id)
+
+	if err != nil {
+		return err
+	}
+
+	lesson.UpdatedByID = deletedBy
+
+	return usecase.repository.DeleteLesson(*lesson)
+}
+
+// DeleteSection implements CurriculumUseCase.
+func (usecase *curriculumUseCase) DeleteSection(id int, deletedBy *int64) *response.Error {
+	section, err := usecase.repository.FindSectionById(id)
+
+	if err != nil {
+		return err
+	}
+
+	section.UpdatedByID = deletedBy
+
+	return usecase.repository.DeleteSection(*section)
+}
+
+// FindLessonById implements CurriculumUseCase.
+// Locked lessons are rejected instead of being returned without content.
+func (usecase *curriculumUseCase) FindLessonById(id int, user *oauthDto.ClaimsResponse) (*dto.LessonOutline, *response.Error) {
+	lesson, err := usecase.repository.FindLessonById(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	outline, err := usecase.lessonOutline(*lesson, usecase.canAccess(lesson.Section.ProductID, user))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if outline.IsLocked {
+		return nil, &response.Error{
+			Code: 403,
+			Err:  errors.New("enroll in this course to open the lesson"),
+		}
+	}
+
+	return outline, nil
+}
+
+// Outline implements CurriculumUseCase.
+// Anyone may see the structure of a course; lesson content is only included
+// for enrolled users, admins and free preview lessons.
+func (usecase *curriculumUseCase) Outline(productID int, user *oauthDto.ClaimsResponse) (*dto.CurriculumResponse, *response.Error) {
+	product, err := usecase.productUseCase.FindOneById(productID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	hasAccess := usecase.canAccess(product.ID, user)
+
+	curriculum := &dto.CurriculumResponse{
+		ProductID:  product.ID,
+		IsEnrolled: hasAccess,
+		Sections:   []dto.SectionOutline{},
+	}
+
+	for _, section := range usecase.repository.FindSectionsByProductId(product.ID) {
+		sectionOutline := dto.SectionOutline{
+			ID:       section.ID,
+			Title:    section.Title,
+			Position: section.Position,
+			Lessons:  []dto.LessonOutline{},
+		}
+
+		for _, lesson := range section.Lessons {
+			lessonOutline, err := usecase.lessonOutline(lesson, hasAccess)
+
+			if err != nil {
+				return nil, err
+			}
+
+			sectionOutline.Duration += lesson.Duration
+			sectionOutline.Lessons = append(sectionOutline.Lessons, *lessonOutline)
+		}
+
+		curriculum.TotalLessons += len(section.Lessons)
+		curriculum.TotalDuration += sectionOutline.Duration
+		curriculum.Sections = append(curriculum.Sections, sectionOutline)
+	}
+
+	return curriculum, nil
+}
+
+// ReorderLessons implements CurriculumUseCase.
+func (usecase *curriculumUseCase) ReorderLessons(sectionID int, dto dto.ReorderRequestBody) ([]entity.Lesson, *response.Error) {
+	section, err := usecase.repository.FindSectionById(sectionID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lessons := usecase.repository.FindLessonsBySectionId(section.ID)
+	current := make([]int64, len(lessons))
+
+	for i, lesson := range lessons {
+		current[i] = lesson.ID
+	}
+
+	if !samePermutation(current, dto.IDs) {
+		return nil, &response.Error{
+			Code: 400,
+			Err:  errors.New("ids must list every lesson of the section exactly once"),
+		}
+	}
+
+	if err := usecase.repository.ReorderLessons(dto.IDs, dto.UpdatedBy); err != nil {
+		return nil, err
+	}
+
+	return usecase.repository.FindLessonsBySectionId(section.ID), nil
+}
+
+// ReorderSections implements CurriculumUseCase.
+func (usecase *curriculumUseCase) ReorderSections(productID int, dto dto.ReorderRequestBody) ([]entity.Section, *response.Error) {
+	product, err := usecase.productUseCase.FindOneById(productID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sections := usecase.repository.FindSectionsByProductId(product.ID)
+	current := make([]int64, len(sections))
+
+	for i, section := range sections {
+		current[i] = section.ID
+	}
+
+	if !samePermutation(current, dto.IDs) {
+		return nil, &response.Error{
+			Code: 400,
+			Err:  errors.New("ids must list every section of the product exactly once"),
+		}
+	}
+
+	if err := usecase.repository.ReorderSections(dto.IDs, dto.UpdatedBy); err != nil {
+		return nil, err
+	}
+
+	return usecase.repository.FindSectionsByProductId(product.ID), nil
+}
+
+// UpdateLesson implements CurriculumUseCase.
+func (usecase *curriculumUseCase) UpdateLesson(id int, dto dto.LessonRequestBody) (*entity.Lesson, *response.Error) {
+	lesson, err := usecase.repository.FindLessonById(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateLesson(dto); err != nil {
+		return nil, err
+	}
+
+	if dto.SectionID != nil && *dto.SectionID != lesson.SectionID {
+		section, err := usecase.repository.FindSectionById(int(*dto.SectionID))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if section.ProductID != lesson.Section.ProductID {
+			return nil, &response.Error{
+				Code: 400,
+				Err:  errors.New("lessons can only be moved between sections of the same product"),
+			}
+		}
+
+		lesson.SectionID = section.ID
+		lesson.Position = usecase.repository.NextLessonPosition(section.ID)
+	}
+
+	lesson.Title = dto.Title
+	lesson.Type = dto.Type
+	lesson.Duration = dto.Duration
+	lesson.IsFreePreview = dto.IsFreePreview
+	lesson.IsOptional = dto.IsOptional
+	lesson.Video = dto.Video
+	lesson.Content = dto.Content
+	lesson.Attachment = dto.Attachment
+	lesson.UpdatedByID = dto.UpdatedBy
+
+	return usecase.repository.UpdateLesson(*lesson)
+}
+
+// UpdateSection implements CurriculumUseCase.
+func (usecase *curriculumUseCase) UpdateSection(id int, dto dto.SectionRequestBody) (*entity.Section, *response.Error) {
+	section, err := usecase.repository.FindSectionById(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	section.Title = dto.Title
+	section.UpdatedByID = dto.UpdatedBy
+
+	return usecase.repository.UpdateSection(*section)
+}
+
+func (usecase *curriculumUseCase) canAccess(productID int64, user *oauthDto.ClaimsResponse) bool {
+	if user == nil {
+		return false
+	}
+
+	return user.IsAdmin || usecase.classRoomUseCase.HasAccess(user.ID, productID)
+}
+
+func (usecase *curriculumUseCase) lessonOutline(lesson entity.Lesson, hasAccess bool) (*dto.LessonOutline, *response.Error) {
+	outline := &dto.LessonOutline{
+		ID:            lesson.ID,
+		SectionID:     lesson.SectionID,
+		Title:         lesson.Title,
+		Type:          lesson.Type,
+		Position:      lesson.Position,
+		Duration:      lesson.Duration,
+		IsFreePreview: lesson.IsFreePreview,
+		IsOptional:    lesson.IsOptional,
+		IsLocked:      !hasAccess && !lesson.IsFreePreview,
+	}
+
+	if outline.IsLocked {
+		return outline, nil
+	}
+
+	outline.Content = lesson.Content
+	outline.Attachment = lesson.Attachment
+
+	if lesson.Video != nil && *lesson.Video != "" {
+		video, err := usecase.mediaUseCase.SignedURL(*lesson.Video)
+
+		if err != nil {
+			return nil, err
+		}
+
+		outline.Video = video
+	}
+
+	return outline, nil
+}
+
+// validateLesson checks that the field matching the lesson type is filled in
+func validateLesson(request dto.LessonRequestBody) *response.Error {
+	var missing string
+
+	switch request.Type {
+	case entity.LessonTypeVideo:
+		if request.Video == nil || *request.Video == "" {
+			missing = "video"
+		}
+	case entity.LessonTypeArticle:
+		if request.Content == nil || *request.Content == "" {
+			missing = "content"
+		}
+	case entity.LessonTypeAttachment:
+		if request.Attachment == nil || *request.Attachment == "" {
+			missing = "attachment"
+		}
+	}
+
+	if missing != "" {
+		return &response.Error{
+			Code: 400,
+			Err:  errors.New(missing + " is required for " + request.Type + " lessons"),
+		}
+	}
+
+	return nil
+}
+
+// samePermutation reports whether ids holds exactly the elements of current
+func samePermutation(current []int64, ids []int64) bool {
+	if len(current) != len(ids) {
+		return false
+	}
+
+	remaining := make(map[int64]bool, len(current))
+
+	for _, id := range current {
+		remaining[id] = true
+	}
+
+	for _, id := range ids {
+		if !remaining[id] {
+			return false
+		}
+
+		delete(remaining, id)
+	}
+
+	return true
+}
+
+func NewCurriculumUseCase(
+	repository repository.CurriculumRepository,
+	productUseCase productUseCase.ProductUseCase,
+	mediaUseCase mediaUseCase.MediaUseCase,
+	classRoomUseCase classRoomUseCase.ClassRoomUseCase,
+) CurriculumUseCase {
+	return &curriculumUseCase{repository, productUseCase, mediaUseCase, classRoomUseCase}
+}
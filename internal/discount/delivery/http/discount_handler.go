@@ -0,0 +1,202 @@
+package discount
+
+import (
+	"net/http"
+	"strconv"
+
+	dto "e-course-management/internal/discount/dto"
+	usecase "e-course-management/internal/discount/usecase"
+	"e-course-management/internal/middleware"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DiscountHandler struct {
+	usecase usecase.DiscountUseCase
+}
+
+func NewDiscountHandler(usecase usecase.DiscountUseCase) *DiscountHandler {
+	return &DiscountHandler{usecase}
+}
+
+func (handler *DiscountHandler) Route(r *gin.RouterGroup) {
+	discountRouter := r.Group("/api/v1")
+
+	discountRouter.POST("/discounts/validate", middleware.AuthJwt, middleware.AuthUser, handler.Validate)
+
+	discountRouter.Use(middleware.AuthJwt, middleware.AuthAdmin)
+	{
+		discountRouter.GET("/discounts", handler.FindAll)
+		discountRouter.GET("/discounts/:id", handler.FindById)
+		discountRouter.POST("/discounts", handler.Create)
+		discountRouter.PATCH("/discounts/:id", handler.Update)
+		discountRouter.DELETE("/discounts/:id", handler.Delete)
+	}
+}
+
+func (handler *DiscountHandler) Validate(ctx *gin.Context) {
+	var input dto.DiscountValidateRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.Validate(user.ID, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *DiscountHandler) FindAll(ctx *gin.Context) {
+	offset, _ := strconv.Atoi(ctx.Query("offset"))
+	limit, _ := strconv.Atoi(ctx.Query("limit"))
+
+	data := handler.usecase.FindAll(offset, limit)
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *DiscountHandler) FindById(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	data, err := handler.usecase.FindOneById(id)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *DiscountHandler) Create(ctx *gin.Context) {
+	var input dto.DiscountRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+	input.CreatedBy = &user.ID
+
+	data, err := handler.usecase.Create(input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Response(
+		http.StatusCreated,
+		http.StatusText(http.StatusCreated),
+		data,
+	))
+}
+
+func (handler *DiscountHandler) Update(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input dto.DiscountRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+	input.UpdatedBy = &user.ID
+
+	data, err := handler.usecase.Update(id, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *DiscountHandler) Delete(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	user := utils.GetCurrentUser(ctx)
+
+	err := handler.usecase.Delete(id, user.ID)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		"ok",
+	))
+}
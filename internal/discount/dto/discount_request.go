@@ -0,0 +1,35 @@
+package discount
+
+import "time"
+
+type DiscountRequestBody struct {
+	Name               string     `json:"name" binding:"required"`
+	Code               string     `json:"code" binding:"required,max=255"`
+	Quantity           int        `json:"quantity" binding:"required,min=1"`
+	Type               string     `json:"type" binding:"required,oneof=percentage fixed"`
+	Value              int64      `json:"value" binding:"required,min=1"`
+	StartDate          *time.Time `json:"start_date"`
+	EndDate            *time.Time `json:"end_date"`
+	MinimumSpend       *int64     `json:"minimum_spend" binding:"omitempty,min=1"`
+	MaximumReduction   *int64     `json:"maximum_reduction" binding:"omitempty,min=1"`
+	OncePerUser        bool       `json:"once_per_user"`
+	FirstOrderOnly     bool       `json:"first_order_only"`
+	IsStackable        bool       `json:"is_stackable"`
+	ProductIDs         []int64    `json:"product_ids"`
+	ProductCategoryIDs []int64    `json:"product_category_ids"`
+	CreatedBy          *int64     `json:"-"`
+	UpdatedBy          *int64     `json:"-"`
+}
+
+// DiscountValidateRequestBody holds the codes to try on the checked cart.
+// More than one code can only be used when all of them are stackable.
+type DiscountValidateRequestBody struct {
+	Codes []string `json:"codes" binding:"required,min=1,max=5,dive,required"`
+}
+
+// DiscountItem is one course the discount would apply to
+type DiscountItem struct {
+	ProductID         int64
+	ProductCategoryID *int64
+	Price             int64
+}
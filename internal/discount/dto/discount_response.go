@@ -0,0 +1,22 @@
+package discount
+
+// DiscountValidationResponse shows what the codes take off the checked cart.
+// Total is Subtotal minus Reduction.
+type DiscountValidationResponse struct {
+	Subtotal  int64             `json:"subtotal"`
+	Reduction int64             `json:"reduction"`
+	Total     int64             `json:"total"`
+	Discounts []AppliedDiscount `json:"discounts"`
+}
+
+// AppliedDiscount is the share of one code in the reduction. EligibleSubtotal
+// is the price of the courses the code applies to.
+type AppliedDiscount struct {
+	DiscountID       int64  `json:"discount_id"`
+	Code             string `json:"code"`
+	Name             string `json:"name"`
+	Type             string `json:"type"`
+	Value            int64  `json:"value"`
+	EligibleSubtotal int64  `json:"eligible_subtotal"`
+	Reduction        int64  `json:"reduction"`
+}
@@ -0,0 +1,58 @@
+package discount
+
+import (
+	admin "e-course-management/internal/admin/entity"
+	product "e-course-management/internal/product/entity"
+	productCategory "e-course-management/internal/product_category/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	TypePercentage = "percentage"
+	TypeFixed      = "fixed"
+)
+
+// Discount is a code taking Value percent or Value off the price of an order.
+// RemainingQuantity counts the redemptions left out of Quantity.
+//
+// When Products or ProductCategories are set the discount only applies to
+// the matching courses, and MinimumSpend is counted on those courses alone.
+// MaximumReduction caps percentage discounts, and only stackable discounts
+// can be used together in one order.
+type Discount struct {
+	ID                int64                             `json:"id"`
+	Name              string                            `json:"name"`
+	Code              string                            `json:"code"`
+	Quantity          int                               `json:"quantity"`
+	RemainingQuantity int                               `json:"remaining_quantity"`
+	Type              string                            `json:"type"`
+	Value             int64                             `json:"value"`
+	StartDate         *time.Time                        `json:"start_date"`
+	EndDate           *time.Time                        `json:"end_date"`
+	MinimumSpend      *int64                            `json:"minimum_spend"`
+	MaximumReduction  *int64                            `json:"maximum_reduction"`
+	OncePerUser       bool                              `json:"once_per_user"`
+	FirstOrderOnly    bool                              `json:"first_order_only"`
+	IsStackable       bool                              `json:"is_stackable"`
+	Products          []product.Product                 `json:"products" gorm:"many2many:discount_products"`
+	ProductCategories []productCategory.ProductCategory `json:"product_categories" gorm:"many2many:discount_product_categories"`
+	CreatedByID       *int64                            `json:"created_by" gorm:"column:created_by"`
+	CreatedBy         *admin.Admin                      `json:"-" gorm:"foreignKey:CreatedByID;references:ID"`
+	UpdatedByID       *int64                            `json:"updated_by" gorm:"column:updated_by"`
+	UpdatedBy         *admin.Admin                      `json:"-" gorm:"foreignKey:UpdatedByID;references:ID"`
+	CreatedAt         *time.Time                        `json:"created_at"`
+	UpdatedAt         *time.Time                        `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt                    `json:"deleted_at"`
+}
+
+type DiscountProduct struct {
+	DiscountID int64 `json:"discount_id" gorm:"primaryKey"`
+	ProductID  int64 `json:"product_id" gorm:"primaryKey"`
+}
+
+type DiscountProductCategory struct {
+	DiscountID        int64 `json:"discount_id" gorm:"primaryKey"`
+	ProductCategoryID int64 `json:"product_category_id" gorm:"primaryKey"`
+}
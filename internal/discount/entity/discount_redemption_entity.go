@@ -0,0 +1,15 @@
+package discount
+
+import "time"
+
+// DiscountRedemption records that an order used up one of the remaining
+// quantity of a discount. ReleasedAt is set once the order expired or was
+// cancelled and the quantity was given back.
+type DiscountRedemption struct {
+	ID         int64      `json:"id"`
+	DiscountID int64      `json:"discount_id"`
+	OrderID    int64      `json:"order_id"`
+	UserID     *int64     `json:"user_id"`
+	ReleasedAt *time.Time `json:"released_at"`
+	CreatedAt  *time.Time `json:"created_at"`
+}
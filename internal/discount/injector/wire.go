@@ -0,0 +1,41 @@
+//go:build wireinject
+// +build wireinject
+
+package discount
+
+import (
+	cartRepository "e-course-management/internal/cart/repository"
+	cartUseCase "e-course-management/internal/cart/usecase"
+	handler "e-course-management/internal/discount/delivery/http"
+	repository "e-course-management/internal/discount/repository"
+	usecase "e-course-management/internal/discount/usecase"
+	mediaRepository "e-course-management/internal/media/repository"
+	mediaUseCase "e-course-management/internal/media/usecase"
+	productRepository "e-course-management/internal/product/repository"
+	productUseCase "e-course-management/internal/product/usecase"
+	productCategoryRepository "e-course-management/internal/product_category/repository"
+	productCategoryUseCase "e-course-management/internal/product_category/usecase"
+	"e-course-management/pkg/storage"
+
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+func InitializedService(db *gorm.DB) *handler.DiscountHandler {
+	wire.Build(
+		handler.NewDiscountHandler,
+		usecase.NewDiscountUseCase,
+		repository.NewDiscountRepository,
+		cartUseCase.NewCartUseCase,
+		cartRepository.NewCartRepository,
+		productUseCase.NewProductUseCase,
+		productRepository.NewProductRepository,
+		productCategoryUseCase.NewProductCategoryUseCase,
+		productCategoryRepository.NewProductCategoryRepository,
+		mediaUseCase.NewMediaUseCase,
+		mediaRepository.NewMediaRepository,
+		storage.NewStorage,
+	)
+
+	return &handler.DiscountHandler{}
+}
@@ -0,0 +1,404 @@
+package discount
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	entity "e-course-management/internal/discount/entity"
+	orderEntity "e-course-management/internal/order/entity"
+	productEntity "e-course-management/internal/product/entity"
+	productCategoryEntity "e-course-management/internal/product_category/entity"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DiscountRepository interface {
+	FindAll(offset int, limit int) []entity.Discount
+	FindOneById(id int) (*entity.Discount, *response.Error)
+	FindOneByCode(code string) (*entity.Discount, *response.Error)
+	CountByCode(code string, exceptID int64) int64
+	CountProducts(ids []int64) int64
+	CountProductCategories(ids []int64) int64
+	CountRedemptionsByUser(discountID int64, userID int64) int64
+	CountOrdersByUserId(userID int64) int64
+	Create(discount entity.Discount) (*entity.Discount, *response.Error)
+	Update(discount entity.Discount) (*entity.Discount, *response.Error)
+	Delete(discount entity.Discount) *response.Error
+	Redeem(discountID int64, orderID int64, userID *int64) *response.Error
+	Release(orderID int64) *response.Error
+	WithTx(tx *gorm.DB) DiscountRepository
+}
+
+type discountRepository struct {
+	db *gorm.DB
+}
+
+// droppedOrderStatuses are the statuses of orders that never went through
+var droppedOrderStatuses = []orderEntity.Status{orderEntity.StatusExpired, orderEntity.StatusCancelled}
+
+// CountByCode implements DiscountRepository.
+// Deleted discounts are counted as well since they still hold their code.
+func (repository *discountRepository) CountByCode(code string, exceptID int64) int64 {
+	var count int64
+
+	repository.db.Unscoped().Model(&entity.Discount{}).
+		Where("code = ? AND id <> ?", code, exceptID).
+		Count(&count)
+
+	return count
+}
+
+// CountOrdersByUserId implements DiscountRepository.
+// Orders still waiting for payment count too, only expired and cancelled
+// orders are left out.
+func (repository *discountRepository) CountOrdersByUserId(userID int64) int64 {
+	var count int64
+
+	repository.db.Model(&orderEntity.Order{}).
+		Where("user_id = ? AND status NOT IN ?", userID, droppedOrderStatuses).
+		Count(&count)
+
+	return count
+}
+
+// CountProductCategories implements DiscountRepository.
+func (repository *discountRepository) CountProductCategories(ids []int64) int64 {
+	var count int64
+
+	repository.db.Model(&productCategoryEntity.ProductCategory{}).Where("id IN ?", ids).Count(&count)
+
+	return count
+}
+
+// CountProducts implements DiscountRepository.
+func (repository *discountRepository) CountProducts(ids []int64) int64 {
+	var count int64
+
+	repository.db.Model(&productEntity.Product{}).Where("id IN ?", ids).Count(&count)
+
+	return count
+}
+
+// CountRedemptionsByUser implements DiscountRepository.
+// Released redemptions do not count.
+func (repository *discountRepository) CountRedemptionsByUser(discountID int64, userID int64) int64 {
+	var count int64
+
+	repository.db.Model(&entity.DiscountRedemption{}).
+		Where("discount_id = ? AND user_id = ? AND released_at IS NULL", discountID, userID).
+		Count(&count)
+
+	return count
+}
+
+// Create implements DiscountRepository.
+func (repository *discountRepository) Create(discount entity.Discount) (*entity.Discount, *response.Error) {
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(&discount).Error; err != nil {
+			return err
+		}
+
+		return replaceTargets(tx, discount)
+	})
+
+	if err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &discount, nil
+}
+
+// Delete implements DiscountRepository.
+func (repository *discountRepository) Delete(discount entity.Discount) *response.Error {
+	if err := utils.SoftDelete(repository.db, &discount, discount.UpdatedByID); err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// FindAll implements DiscountRepository.
+func (repository *discountRepository) FindAll(offset int, limit int) []entity.Discount {
+	var discounts []entity.Discount
+
+	repository.db.
+		Preload("Products").
+		Preload("ProductCategories").
+		Scopes(utils.Paginate(offset, limit)).
+		Order("id DESC").
+		Find(&discounts)
+
+	return discounts
+}
+
+// FindOneByCode implements DiscountRepository.
+func (repository *discountRepository) FindOneByCode(code string) (*entity.Discount, *response.Error) {
+	var discount entity.Discount
+
+	err := repository.db.
+		Preload("Products").
+		Preload("ProductCategories").
+		Where("code = ?", code).
+		First(&discount).
+		Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("discount not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &discount, nil
+}
+
+// FindOneById implements DiscountRepository.
+func (repository *discountRepository) FindOneById(id int) (*entity.Discount, *response.Error) {
+	var discount entity.Discount
+
+	err := repository.db.
+		Preload("Products").
+		Preload("ProductCategories").
+		First(&discount, id).
+		Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("discount not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &discount, nil
+}
+
+// Redeem implements DiscountRepository.
+// The remaining quantity is taken with a conditional update, so concurrent
+// checkouts queue on the row lock and can never take more than is left. The
+// lock also makes the once per user and first order checks see redemptions
+// and orders made meanwhile.
+func (repository *discountRepository) Redeem(discountID int64, orderID int64, userID *int64) *response.Error {
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Discount{}).
+			Where("id = ? AND remaining_quantity > 0", discountID).
+			Update("remaining_quantity", gorm.Expr("remaining_quantity - 1"))
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return &response.Error{
+				Code: 400,
+				Err:  errors.New("discount has been fully redeemed"),
+			}
+		}
+
+		var discount entity.Discount
+
+		if err := tx.Select("id, code, once_per_user, first_order_only").First(&discount, discountID).Error; err != nil {
+			return err
+		}
+
+		if discount.OncePerUser && userID != nil {
+			var count int64
+
+			err := tx.Model(&entity.DiscountRedemption{}).
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("discount_id = ? AND user_id = ? AND released_at IS NULL", discountID, *userID).
+				Count(&count).
+				Error
+
+			if err != nil {
+				return err
+			}
+
+			if count > 0 {
+				return &response.Error{
+					Code: 400,
+					Err:  fmt.Errorf("you have already used discount %s", discount.Code),
+				}
+			}
+		}
+
+		if discount.FirstOrderOnly && userID != nil {
+			var count int64
+
+			err := tx.Model(&orderEntity.Order{}).
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("user_id = ? AND id <> ? AND status NOT IN ?", *userID, orderID, droppedOrderStatuses).
+				Count(&count).
+				Error
+
+			if err != nil {
+				return err
+			}
+
+			if count > 0 {
+				return &response.Error{
+					Code: 400,
+					Err:  fmt.Errorf("discount %s is only for your first order", discount.Code),
+				}
+			}
+		}
+
+		return tx.Create(&entity.DiscountRedemption{
+			DiscountID: discountID,
+			OrderID:    orderID,
+			UserID:     userID,
+		}).Error
+	})
+
+	return response.FromError(err)
+}
+
+// Release implements DiscountRepository.
+// Each redemption of the order is released at most once, so retrying after
+// an expiry or cancellation does not hand out extra quantity.
+func (repository *discountRepository) Release(orderID int64) *response.Error {
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		var redemptions []entity.DiscountRedemption
+
+		if err := tx.Where("order_id = ? AND released_at IS NULL", orderID).Find(&redemptions).Error; err != nil {
+			return err
+		}
+
+		for _, redemption := range redemptions {
+			result := tx.Model(&entity.DiscountRedemption{}).
+				Where("id = ? AND released_at IS NULL", redemption.ID).
+				Update("released_at", time.Now())
+
+			if result.Error != nil {
+				return result.Error
+			}
+
+			if result.RowsAffected == 0 {
+				continue
+			}
+
+			err := tx.Unscoped().Model(&entity.Discount{}).
+				Where("id = ? AND remaining_quantity < quantity", redemption.DiscountID).
+				Update("remaining_quantity", gorm.Expr("remaining_quantity + 1")).
+				Error
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return response.FromError(err)
+}
+
+// Update implements DiscountRepository.
+// The row is locked so that the remaining quantity moves by the change in
+// quantity without losing redemptions made in the meantime.
+func (repository *discountRepository) Update(discount entity.Discount) (*entity.Discount, *response.Error) {
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		var current entity.Discount
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, discount.ID).Error; err != nil {
+			return err
+		}
+
+		discount.RemainingQuantity = current.RemainingQuantity + discount.Quantity - current.Quantity
+
+		if discount.RemainingQuantity < 0 {
+			return &response.Error{
+				Code: 400,
+				Err:  errors.New("quantity is lower than the number of redemptions"),
+			}
+		}
+
+		if err := tx.Omit(clause.Associations).Save(&discount).Error; err != nil {
+			return err
+		}
+
+		return replaceTargets(tx, discount)
+	})
+
+	if err != nil {
+		return nil, response.FromError(err)
+	}
+
+	return &discount, nil
+}
+
+// WithTx implements DiscountRepository.
+func (repository *discountRepository) WithTx(tx *gorm.DB) DiscountRepository {
+	return &discountRepository{tx}
+}
+
+// replaceTargets stores the products and product categories the discount is
+// limited to
+func replaceTargets(tx *gorm.DB, discount entity.Discount) error {
+	if err := tx.Where("discount_id = ?", discount.ID).Delete(&entity.DiscountProduct{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("discount_id = ?", discount.ID).Delete(&entity.DiscountProductCategory{}).Error; err != nil {
+		return err
+	}
+
+	if len(discount.Products) > 0 {
+		discountProducts := make([]entity.DiscountProduct, 0, len(discount.Products))
+
+		for _, product := range discount.Products {
+			discountProducts = append(discountProducts, entity.DiscountProduct{
+				DiscountID: discount.ID,
+				ProductID:  product.ID,
+			})
+		}
+
+		if err := tx.Create(&discountProducts).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(discount.ProductCategories) > 0 {
+		discountProductCategories := make([]entity.DiscountProductCategory, 0, len(discount.ProductCategories))
+
+		for _, productCategory := range discount.ProductCategories {
+			discountProductCategories = append(discountProductCategories, entity.DiscountProductCategory{
+				DiscountID:        discount.ID,
+				ProductCategoryID: productCategory.ID,
+			})
+		}
+
+		if err := tx.Create(&discountProductCategories).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func NewDiscountRepository(db *gorm.DB) DiscountRepository {
+	return &discountRepository{db}
+}
@@ -0,0 +1,137 @@
+package discount
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	entity "e-course-management/internal/discount/entity"
+	orderEntity "e-course-management/internal/order/entity"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// TestRedeemConcurrently needs a migrated MySQL database, e.g.
+// MYSQL_TEST_DSN="user:pass@tcp(127.0.0.1:3306)/e_course_test?parseTime=true"
+func TestRedeemConcurrently(t *testing.T) {
+	dsn := os.Getenv("MYSQL_TEST_DSN")
+
+	if dsn == "" {
+		t.Skip("MYSQL_TEST_DSN is not set")
+	}
+
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+
+	const quantity = 5
+	const checkouts = 40
+
+	discount := entity.Discount{
+		Name:              "Concurrency test",
+		Code:              fmt.Sprintf("RACE%d", time.Now().UnixNano()),
+		Quantity:          quantity,
+		RemainingQuantity: quantity,
+		Type:              entity.TypeFixed,
+		Value:             1000,
+	}
+
+	if err := db.Create(&discount).Error; err != nil {
+		t.Fatalf("create discount: %v", err)
+	}
+
+	orders := make([]orderEntity.Order, checkouts)
+
+	for i := range orders {
+		orders[i] = orderEntity.Order{Price: 10000, TotalPrice: 9000, Status: orderEntity.StatusPending}
+	}
+
+	if err := db.Create(&orders).Error; err != nil {
+		t.Fatalf("create orders: %v", err)
+	}
+
+	t.Cleanup(func() {
+		for _, order := range orders {
+			db.Unscoped().Delete(&order)
+		}
+
+		db.Unscoped().Delete(&discount)
+	})
+
+	repository := NewDiscountRepository(db)
+
+	var redeemed int64
+	var redeemedOrderID int64
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+
+	for _, order := range orders {
+		wg.Add(1)
+
+		go func(orderID int64) {
+			defer wg.Done()
+
+			<-start
+
+			err := db.Transaction(func(tx *gorm.DB) error {
+				if err := repository.WithTx(tx).Redeem(discount.ID, orderID, nil); err != nil {
+					return err
+				}
+
+				return nil
+			})
+
+			if err == nil {
+				atomic.AddInt64(&redeemed, 1)
+				atomic.StoreInt64(&redeemedOrderID, orderID)
+			}
+		}(order.ID)
+	}
+
+	close(start)
+	wg.Wait()
+
+	if redeemed != quantity {
+		t.Fatalf("redeemed %d times, want %d", redeemed, quantity)
+	}
+
+	assertRemaining(t, db, discount.ID, 0)
+
+	var redemptions int64
+
+	db.Model(&entity.DiscountRedemption{}).Where("discount_id = ?", discount.ID).Count(&redemptions)
+
+	if redemptions != quantity {
+		t.Fatalf("recorded %d redemptions, want %d", redemptions, quantity)
+	}
+
+	// releasing the same order twice gives back a single redemption
+	for i := 0; i < 2; i++ {
+		if err := repository.Release(redeemedOrderID); err != nil {
+			t.Fatalf("release: %v", err)
+		}
+	}
+
+	assertRemaining(t, db, discount.ID, 1)
+}
+
+func assertRemaining(t *testing.T, db *gorm.DB, discountID int64, want int) {
+	t.Helper()
+
+	var discount entity.Discount
+
+	if err := db.First(&discount, discountID).Error; err != nil {
+		t.Fatalf("find discount: %v", err)
+	}
+
+	if discount.RemainingQuantity != want {
+		t.Fatalf("remaining quantity is %d, want %d", discount.RemainingQuantity, want)
+	}
+}
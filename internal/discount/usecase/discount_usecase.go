@@ -0,0 +1,445 @@
+package discount
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	cartUseCase "e-course-management/internal/cart/usecase"
+	dto "e-course-management/internal/discount/dto"
+	entity "e-course-management/internal/discount/entity"
+	repository "e-course-management/internal/discount/repository"
+	productEntity "e-course-management/internal/product/entity"
+	productCategoryEntity "e-course-management/internal/product_category/entity"
+	"e-course-management/pkg/response"
+
+	"gorm.io/gorm"
+)
+
+type DiscountUseCase interface {
+	FindAll(offset int, limit int) []entity.Discount
+	FindOneById(id int) (*entity.Discount, *response.Error)
+	Create(request dto.DiscountRequestBody) (*entity.Discount, *response.Error)
+	Update(id int, request dto.DiscountRequestBody) (*entity.Discount, *response.Error)
+	Delete(id int, deletedBy int64) *response.Error
+	Validate(userID int64, request dto.DiscountValidateRequestBody) (*dto.DiscountValidationResponse, *response.Error)
+	Calculate(codes []string, userID int64, items []dto.DiscountItem) (*dto.DiscountValidationResponse, *response.Error)
+	Redeem(discountID int64, orderID int64, userID *int64) *response.Error
+	Release(orderID int64) *response.Error
+	WithTx(tx *gorm.DB) DiscountUseCase
+}
+
+type discountUseCase struct {
+	repository  repository.DiscountRepository
+	cartUseCase cartUseCase.CartUseCase
+}
+
+// Calculate implements DiscountUseCase.
+// The codes are applied one after the other on what is left of the total.
+// Percentage reductions are rounded down to a whole unit, and no code takes
+// more than the price of the courses it applies to.
+func (usecase *discountUseCase) Calculate(codes []string, userID int64, items []dto.DiscountItem) (*dto.DiscountValidationResponse, *response.Error) {
+	if len(items) == 0 {
+		return nil, &response.Error{
+			Code: 400,
+			Err:  errors.New("there are no courses to apply the discount to"),
+		}
+	}
+
+	result := &dto.DiscountValidationResponse{
+		Discounts: []dto.AppliedDiscount{},
+	}
+
+	for _, item := range items {
+		result.Subtotal += item.Price
+	}
+
+	var discounts []entity.Discount
+
+	seen := map[string]bool{}
+
+	for _, code := range codes {
+		code = normalizeCode(code)
+
+		if seen[code] {
+			continue
+		}
+
+		seen[code] = true
+
+		discount, err := usecase.repository.FindOneByCode(code)
+
+		if err != nil {
+			if err.Code == 404 {
+				err.Err = fmt.Errorf("discount %s not found", code)
+			}
+
+			return nil, err
+		}
+
+		eligible := eligibleSubtotal(*discount, items)
+
+		if eligible == 0 {
+			return nil, &response.Error{
+				Code: 400,
+				Err:  fmt.Errorf("discount %s does not apply to any course in your cart", discount.Code),
+			}
+		}
+
+		if err := usecase.check(*discount, userID, eligible); err != nil {
+			return nil, err
+		}
+
+		discounts = append(discounts, *discount)
+	}
+
+	if len(discounts) > 1 {
+		for _, discount := range discounts {
+			if !discount.IsStackable {
+				return nil, &response.Error{
+					Code: 400,
+					Err:  fmt.Errorf("discount %s can not be combined with other discounts", discount.Code),
+				}
+			}
+		}
+	}
+
+	total := result.Subtotal
+
+	for _, discount := range discounts {
+		eligibleSubtotal := eligibleSubtotal(discount, items)
+
+		reduction := discount.Value
+
+		if discount.Type == entity.TypePercentage {
+			reduction = eligibleSubtotal * discount.Value / 100
+
+			if discount.MaximumReduction != nil && reduction > *discount.MaximumReduction {
+				reduction = *discount.MaximumReduction
+			}
+		}
+
+		if reduction > eligibleSubtotal {
+			reduction = eligibleSubtotal
+		}
+
+		if reduction > total {
+			reduction = total
+		}
+
+		total -= reduction
+
+		result.Discounts = append(result.Discounts, dto.AppliedDiscount{
+			DiscountID:       discount.ID,
+			Code:             discount.Code,
+			Name:             discount.Name,
+			Type:             discount.Type,
+			Value:            discount.Value,
+			EligibleSubtotal: eligibleSubtotal,
+			Reduction:        reduction,
+		})
+	}
+
+	result.Reduction = result.Subtotal - total
+	result.Total = total
+
+	return result, nil
+}
+
+// Create implements DiscountUseCase.
+func (usecase *discountUseCase) Create(request dto.DiscountRequestBody) (*entity.Discount, *response.Error) {
+	discount := entity.Discount{
+		Quantity:          request.Quantity,
+		RemainingQuantity: request.Quantity,
+		CreatedByID:       request.CreatedBy,
+	}
+
+	if err := usecase.fill(&discount, request); err != nil {
+		return nil, err
+	}
+
+	created, err := usecase.repository.Create(discount)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return usecase.repository.FindOneById(int(created.ID))
+}
+
+// Delete implements DiscountUseCase.
+func (usecase *discountUseCase) Delete(id int, deletedBy int64) *response.Error {
+	discount, err := usecase.repository.FindOneById(id)
+
+	if err != nil {
+		return err
+	}
+
+	discount.UpdatedByID = &deletedBy
+
+	return usecase.repository.Delete(*discount)
+}
+
+// FindAll implements DiscountUseCase.
+func (usecase *discountUseCase) FindAll(offset int, limit int) []entity.Discount {
+	return usecase.repository.FindAll(offset, limit)
+}
+
+// FindOneById implements DiscountUseCase.
+func (usecase *discountUseCase) FindOneById(id int) (*entity.Discount, *response.Error) {
+	return usecase.repository.FindOneById(id)
+}
+
+// Redeem implements DiscountUseCase.
+// It takes one of the remaining quantity for the order, and is meant to run
+// in the transaction creating the order.
+func (usecase *discountUseCase) Redeem(discountID int64, orderID int64, userID *int64) *response.Error {
+	return usecase.repository.Redeem(discountID, orderID, userID)
+}
+
+// Release implements DiscountUseCase.
+// It gives back the quantity taken by an expired or cancelled order.
+func (usecase *discountUseCase) Release(orderID int64) *response.Error {
+	return usecase.repository.Release(orderID)
+}
+
+// Update implements DiscountUseCase.
+// Changing the quantity moves the remaining quantity by the same amount, and
+// can not go below what has already been redeemed.
+func (usecase *discountUseCase) Update(id int, request dto.DiscountRequestBody) (*entity.Discount, *response.Error) {
+	discount, err := usecase.repository.FindOneById(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	discount.Quantity = request.Quantity
+	discount.UpdatedByID = request.UpdatedBy
+
+	if err := usecase.fill(discount, request); err != nil {
+		return nil, err
+	}
+
+	if _, err := usecase.repository.Update(*discount); err != nil {
+		return nil, err
+	}
+
+	return usecase.repository.FindOneById(id)
+}
+
+// Validate implements DiscountUseCase.
+// The discounts are applied to the checked courses in the cart of the user.
+func (usecase *discountUseCase) Validate(userID int64, request dto.DiscountValidateRequestBody) (*dto.DiscountValidationResponse, *response.Error) {
+	var items []dto.DiscountItem
+
+	for _, cart := range usecase.cartUseCase.FindAll(userID).Carts {
+		if cart.IsChecked {
+			items = append(items, dto.DiscountItem{
+				ProductID:         cart.Product.ID,
+				ProductCategoryID: cart.Product.ProductCategoryID,
+				Price:             cart.Product.Price,
+			})
+		}
+	}
+
+	return usecase.Calculate(request.Codes, userID, items)
+}
+
+// WithTx implements DiscountUseCase.
+func (usecase *discountUseCase) WithTx(tx *gorm.DB) DiscountUseCase {
+	return &discountUseCase{usecase.repository.WithTx(tx), usecase.cartUseCase}
+}
+
+// check tells why a discount can not be used by the user, if it can not.
+// eligibleSubtotal is the price of the courses in the cart the discount
+// applies to, the minimum spend is compared against it.
+func (usecase *discountUseCase) check(discount entity.Discount, userID int64, eligibleSubtotal int64) *response.Error {
+	now := time.Now()
+
+	if discount.StartDate != nil && now.Before(*discount.StartDate) {
+		return &response.Error{
+			Code: 400,
+			Err:  fmt.Errorf("discount %s is not active yet", discount.Code),
+		}
+	}
+
+	if discount.EndDate != nil && now.After(*discount.EndDate) {
+		return &response.Error{
+			Code: 400,
+			Err:  fmt.Errorf("discount %s has expired", discount.Code),
+		}
+	}
+
+	if discount.RemainingQuantity <= 0 {
+		return &response.Error{
+			Code: 400,
+			Err:  fmt.Errorf("discount %s has been fully redeemed", discount.Code),
+		}
+	}
+
+	if discount.MinimumSpend != nil && eligibleSubtotal < *discount.MinimumSpend {
+		return &response.Error{
+			Code: 400,
+			Err:  fmt.Errorf("discount %s requires a minimum spend of %d on the courses it applies to", discount.Code, *discount.MinimumSpend),
+		}
+	}
+
+	if discount.OncePerUser && usecase.repository.CountRedemptionsByUser(discount.ID, userID) > 0 {
+		return &response.Error{
+			Code: 400,
+			Err:  fmt.Errorf("you have already used discount %s", discount.Code),
+		}
+	}
+
+	if discount.FirstOrderOnly && usecase.repository.CountOrdersByUserId(userID) > 0 {
+		return &response.Error{
+			Code: 400,
+			Err:  fmt.Errorf("discount %s is only for your first order", discount.Code),
+		}
+	}
+
+	return nil
+}
+
+// fill copies the request onto the discount after checking the code is free
+// and the value, dates and targets make sense
+func (usecase *discountUseCase) fill(discount *entity.Discount, request dto.DiscountRequestBody) *response.Error {
+	code := normalizeCode(request.Code)
+
+	if code == "" {
+		return &response.Error{
+			Code: 400,
+			Err:  errors.New("code is required"),
+		}
+	}
+
+	if usecase.repository.CountByCode(code, discount.ID) > 0 {
+		return &response.Error{
+			Code: 409,
+			Err:  errors.New("discount code is already used"),
+		}
+	}
+
+	if request.Type == entity.TypePercentage && request.Value > 100 {
+		return &response.Error{
+			Code: 400,
+			Err:  errors.New("percentage discount can not exceed 100"),
+		}
+	}
+
+	if request.Type == entity.TypeFixed && request.MaximumReduction != nil {
+		return &response.Error{
+			Code: 400,
+			Err:  errors.New("maximum reduction only applies to percentage discounts"),
+		}
+	}
+
+	if request.StartDate != nil && request.EndDate != nil && request.EndDate.Before(*request.StartDate) {
+		return &response.Error{
+			Code: 400,
+			Err:  errors.New("end date must be after start date"),
+		}
+	}
+
+	productIDs := uniqueIDs(request.ProductIDs)
+
+	if len(productIDs) > 0 && usecase.repository.CountProducts(productIDs) != int64(len(productIDs)) {
+		return &response.Error{
+			Code: 400,
+			Err:  errors.New("some products do not exist"),
+		}
+	}
+
+	productCategoryIDs := uniqueIDs(request.ProductCategoryIDs)
+
+	if len(productCategoryIDs) > 0 && usecase.repository.CountProductCategories(productCategoryIDs) != int64(len(productCategoryIDs)) {
+		return &response.Error{
+			Code: 400,
+			Err:  errors.New("some product categories do not exist"),
+		}
+	}
+
+	discount.Products = make([]productEntity.Product, 0, len(productIDs))
+
+	for _, id := range productIDs {
+		discount.Products = append(discount.Products, productEntity.Product{ID: id})
+	}
+
+	discount.ProductCategories = make([]productCategoryEntity.ProductCategory, 0, len(productCategoryIDs))
+
+	for _, id := range productCategoryIDs {
+		discount.ProductCategories = append(discount.ProductCategories, productCategoryEntity.ProductCategory{ID: id})
+	}
+
+	discount.Name = request.Name
+	discount.Code = code
+	discount.Type = request.Type
+	discount.Value = request.Value
+	discount.StartDate = request.StartDate
+	discount.EndDate = request.EndDate
+	discount.MinimumSpend = request.MinimumSpend
+	discount.MaximumReduction = request.MaximumReduction
+	discount.OncePerUser = request.OncePerUser
+	discount.FirstOrderOnly = request.FirstOrderOnly
+	discount.IsStackable = request.IsStackable
+
+	return nil
+}
+
+// eligibleSubtotal sums the price of the items the discount applies to, all
+// of them when it is not limited to some products or categories
+func eligibleSubtotal(discount entity.Discount, items []dto.DiscountItem) int64 {
+	targeted := len(discount.Products) > 0 || len(discount.ProductCategories) > 0
+
+	products := map[int64]bool{}
+	productCategories := map[int64]bool{}
+
+	for _, product := range discount.Products {
+		products[product.ID] = true
+	}
+
+	for _, productCategory := range discount.ProductCategories {
+		productCategories[productCategory.ID] = true
+	}
+
+	var subtotal int64
+
+	for _, item := range items {
+		eligible := !targeted ||
+			products[item.ProductID] ||
+			(item.ProductCategoryID != nil && productCategories[*item.ProductCategoryID])
+
+		if eligible {
+			subtotal += item.Price
+		}
+	}
+
+	return subtotal
+}
+
+func uniqueIDs(ids []int64) []int64 {
+	seen := map[int64]bool{}
+	result := []int64{}
+
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
+
+// normalizeCode makes codes case insensitive
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func NewDiscountUseCase(
+	repository repository.DiscountRepository,
+	cartUseCase cartUseCase.CartUseCase,
+) DiscountUseCase {
+	return &discountUseCase{repository, cartUseCase}
+}
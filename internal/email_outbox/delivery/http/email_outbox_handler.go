@@ -0,0 +1,88 @@
+package email_outbox
+
+import (
+	"net/http"
+	"strconv"
+
+	usecase "e-course-management/internal/email_outbox/usecase"
+	"e-course-management/internal/middleware"
+	"e-course-management/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EmailOutboxHandler struct {
+	usecase usecase.EmailOutboxUseCase
+}
+
+func NewEmailOutboxHandler(usecase usecase.EmailOutboxUseCase) *EmailOutboxHandler {
+	return &EmailOutboxHandler{usecase}
+}
+
+func (handler *EmailOutboxHandler) Route(r *gin.RouterGroup) {
+	emailOutboxRouter := r.Group("/api/v1")
+
+	emailOutboxRouter.Use(middleware.AuthJwt, middleware.AuthAdmin)
+	{
+		emailOutboxRouter.GET("/email_outboxes", handler.FindAll)
+		emailOutboxRouter.GET("/email_outboxes/:id", handler.FindById)
+		emailOutboxRouter.POST("/email_outboxes/:id/resend", handler.Resend)
+	}
+}
+
+func (handler *EmailOutboxHandler) FindAll(ctx *gin.Context) {
+	offset, _ := strconv.Atoi(ctx.Query("offset"))
+	limit, _ := strconv.Atoi(ctx.Query("limit"))
+
+	data := handler.usecase.FindAll(offset, limit, ctx.Query("status"))
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *EmailOutboxHandler) FindById(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	data, err := handler.usecase.FindOneById(id)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *EmailOutboxHandler) Resend(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	data, err := handler.usecase.Resend(id)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
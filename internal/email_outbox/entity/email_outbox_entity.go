@@ -0,0 +1,34 @@
+package email_outbox
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	StatusPending = "pending"
+	StatusSent    = "sent"
+	StatusDead    = "dead"
+	// The recipient is on the suppression list, the email is never delivered
+	StatusSuppressed = "suppressed"
+)
+
+type EmailOutbox struct {
+	ID            int64          `json:"id"`
+	ToEmail       string         `json:"to_email"`
+	Subject       string         `json:"subject"`
+	Template      string         `json:"template"`
+	Locale        string         `json:"locale"`
+	HtmlBody      string         `json:"html_body"`
+	TextBody      string         `json:"text_body"`
+	Status        string         `json:"status"`
+	Attempts      int            `json:"attempts"`
+	MaxAttempts   int            `json:"max_attempts"`
+	NextAttemptAt *time.Time     `json:"next_attempt_at"`
+	LastError     *string        `json:"last_error"`
+	SentAt        *time.Time     `json:"sent_at"`
+	CreatedAt     *time.Time     `json:"created_at"`
+	UpdatedAt     *time.Time     `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"deleted_at"`
+}
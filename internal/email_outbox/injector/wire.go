@@ -0,0 +1,38 @@
+//go:build wireinject
+// +build wireinject
+
+package email_outbox
+
+import (
+	handler "e-course-management/internal/email_outbox/delivery/http"
+	repository "e-course-management/internal/email_outbox/repository"
+	usecase "e-course-management/internal/email_outbox/usecase"
+	emailSuppressionRepository "e-course-management/internal/email_suppression/repository"
+	mail "e-course-management/pkg/mail/sendgrid"
+
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+func InitializedService(db *gorm.DB) *handler.EmailOutboxHandler {
+	wire.Build(
+		handler.NewEmailOutboxHandler,
+		usecase.NewEmailOutboxUseCase,
+		repository.NewEmailOutboxRepository,
+		emailSuppressionRepository.NewEmailSuppressionRepository,
+		mail.NewTransport,
+	)
+
+	return &handler.EmailOutboxHandler{}
+}
+
+func InitializedWorker(db *gorm.DB) usecase.EmailOutboxUseCase {
+	wire.Build(
+		usecase.NewEmailOutboxUseCase,
+		repository.NewEmailOutboxRepository,
+		emailSuppressionRepository.NewEmailSuppressionRepository,
+		mail.NewTransport,
+	)
+
+	return nil
+}
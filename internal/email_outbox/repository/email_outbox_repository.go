@@ -0,0 +1,137 @@
+package email_outbox
+
+import (
+	"errors"
+	"time"
+
+	entity "e-course-management/internal/email_outbox/entity"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EmailOutboxRepository interface {
+	FindAll(offset int, limit int, status string) []entity.EmailOutbox
+	FindOneById(id int) (*entity.EmailOutbox, *response.Error)
+	Create(entity entity.EmailOutbox) (*entity.EmailOutbox, *response.Error)
+	Update(entity entity.EmailOutbox) (*entity.EmailOutbox, *response.Error)
+	ClaimDue(limit int, lease time.Duration) ([]entity.EmailOutbox, *response.Error)
+	WithTx(tx *gorm.DB) EmailOutboxRepository
+}
+
+type emailOutboxRepository struct {
+	db *gorm.DB
+}
+
+// ClaimDue implements EmailOutboxRepository.
+// Due rows are locked with SKIP LOCKED and their next attempt is pushed back by
+// the lease, so concurrent workers never pick up the same email twice.
+func (repository *emailOutboxRepository) ClaimDue(limit int, lease time.Duration) ([]entity.EmailOutbox, *response.Error) {
+	var emailOutboxes []entity.EmailOutbox
+
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", entity.StatusPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&emailOutboxes).Error; err != nil {
+			return err
+		}
+
+		if len(emailOutboxes) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, len(emailOutboxes))
+
+		for i, emailOutbox := range emailOutboxes {
+			ids[i] = emailOutbox.ID
+		}
+
+		return tx.Model(&entity.EmailOutbox{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+
+	if err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return emailOutboxes, nil
+}
+
+// Create implements EmailOutboxRepository.
+func (repository *emailOutboxRepository) Create(entity entity.EmailOutbox) (*entity.EmailOutbox, *response.Error) {
+	if err := repository.db.Create(&entity).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &entity, nil
+}
+
+// FindAll implements EmailOutboxRepository.
+func (repository *emailOutboxRepository) FindAll(offset int, limit int, status string) []entity.EmailOutbox {
+	var emailOutboxes []entity.EmailOutbox
+
+	query := repository.db.Scopes(utils.Paginate(offset, limit)).Order("id DESC")
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	query.Find(&emailOutboxes)
+
+	return emailOutboxes
+}
+
+// FindOneById implements EmailOutboxRepository.
+func (repository *emailOutboxRepository) FindOneById(id int) (*entity.EmailOutbox, *response.Error) {
+	var emailOutbox entity.EmailOutbox
+
+	if err := repository.db.First(&emailOutbox, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("email not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &emailOutbox, nil
+}
+
+// Update implements EmailOutboxRepository.
+func (repository *emailOutboxRepository) Update(entity entity.EmailOutbox) (*entity.EmailOutbox, *response.Error) {
+	if err := repository.db.Save(&entity).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &entity, nil
+}
+
+// WithTx implements EmailOutboxRepository.
+func (repository *emailOutboxRepository) WithTx(tx *gorm.DB) EmailOutboxRepository {
+	return &emailOutboxRepository{tx}
+}
+
+func NewEmailOutboxRepository(db *gorm.DB) EmailOutboxRepository {
+	return &emailOutboxRepository{db}
+}
@@ -0,0 +1,163 @@
+package email_outbox
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	entity "e-course-management/internal/email_outbox/entity"
+	repository "e-course-management/internal/email_outbox/repository"
+	emailSuppressionRepository "e-course-management/internal/email_suppression/repository"
+	mail "e-course-management/pkg/mail/sendgrid"
+	"e-course-management/pkg/response"
+)
+
+const (
+	batchSize    = 20
+	claimLease   = 5 * time.Minute
+	baseBackoff  = 30 * time.Second
+	maxBackoff   = 6 * time.Hour
+	pollInterval = 10 * time.Second
+)
+
+type EmailOutboxUseCase interface {
+	FindAll(offset int, limit int, status string) []entity.EmailOutbox
+	FindOneById(id int) (*entity.EmailOutbox, *response.Error)
+	Resend(id int) (*entity.EmailOutbox, *response.Error)
+	Dispatch() int
+	Run()
+}
+
+type emailOutboxUseCase struct {
+	repository                 repository.EmailOutboxRepository
+	emailSuppressionRepository emailSuppressionRepository.EmailSuppressionRepository
+	transport                  mail.Transport
+}
+
+// Dispatch implements EmailOutboxUseCase.
+// It delivers one batch of due emails and returns how many were sent.
+func (usecase *emailOutboxUseCase) Dispatch() int {
+	emailOutboxes, err := usecase.repository.ClaimDue(batchSize, claimLease)
+
+	if err != nil {
+		fmt.Println(err.Err)
+		return 0
+	}
+
+	sent := 0
+
+	for _, emailOutbox := range emailOutboxes {
+		// The address may have been suppressed after the email was queued
+		if usecase.emailSuppressionRepository.IsSuppressed(emailOutbox.ToEmail) {
+			emailOutbox.Status = entity.StatusSuppressed
+
+			if _, err := usecase.repository.Update(emailOutbox); err != nil {
+				fmt.Println(err.Err)
+			}
+
+			continue
+		}
+
+		errSend := usecase.transport.Send(emailOutbox.ToEmail, emailOutbox.Subject, emailOutbox.HtmlBody, emailOutbox.TextBody)
+
+		emailOutbox.Attempts++
+		now := time.Now()
+
+		if errSend == nil {
+			emailOutbox.Status = entity.StatusSent
+			emailOutbox.SentAt = &now
+			emailOutbox.LastError = nil
+			sent++
+		} else {
+			lastError := errSend.Error()
+			emailOutbox.LastError = &lastError
+
+			if emailOutbox.Attempts >= emailOutbox.MaxAttempts {
+				emailOutbox.Status = entity.StatusDead
+			} else {
+				nextAttemptAt := now.Add(backoff(emailOutbox.Attempts))
+				emailOutbox.NextAttemptAt = &nextAttemptAt
+			}
+		}
+
+		if _, err := usecase.repository.Update(emailOutbox); err != nil {
+			fmt.Println(err.Err)
+		}
+	}
+
+	return sent
+}
+
+// Run implements EmailOutboxUseCase.
+func (usecase *emailOutboxUseCase) Run() {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		usecase.Dispatch()
+	}
+}
+
+// FindAll implements EmailOutboxUseCase.
+func (usecase *emailOutboxUseCase) FindAll(offset int, limit int, status string) []entity.EmailOutbox {
+	return usecase.repository.FindAll(offset, limit, status)
+}
+
+// FindOneById implements EmailOutboxUseCase.
+func (usecase *emailOutboxUseCase) FindOneById(id int) (*entity.EmailOutbox, *response.Error) {
+	return usecase.repository.FindOneById(id)
+}
+
+// Resend implements EmailOutboxUseCase.
+func (usecase *emailOutboxUseCase) Resend(id int) (*entity.EmailOutbox, *response.Error) {
+	emailOutbox, err := usecase.repository.FindOneById(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if emailOutbox.Status == entity.StatusSent {
+		return nil, &response.Error{
+			Code: 400,
+			Err:  errors.New("email is already sent"),
+		}
+	}
+
+	if usecase.emailSuppressionRepository.IsSuppressed(emailOutbox.ToEmail) {
+		return nil, &response.Error{
+			Code: 400,
+			Err:  errors.New("recipient is on the suppression list"),
+		}
+	}
+
+	now := time.Now()
+
+	emailOutbox.Status = entity.StatusPending
+	emailOutbox.Attempts = 0
+	emailOutbox.NextAttemptAt = &now
+
+	return usecase.repository.Update(*emailOutbox)
+}
+
+// backoff doubles the delay after every failed attempt, capped at maxBackoff
+func backoff(attempts int) time.Duration {
+	delay := baseBackoff
+
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+
+	return delay
+}
+
+func NewEmailOutboxUseCase(
+	repository repository.EmailOutboxRepository,
+	emailSuppressionRepository emailSuppressionRepository.EmailSuppressionRepository,
+	transport mail.Transport,
+) EmailOutboxUseCase {
+	return &emailOutboxUseCase{repository, emailSuppressionRepository, transport}
+}
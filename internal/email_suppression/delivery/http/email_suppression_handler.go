@@ -0,0 +1,104 @@
+package email_suppression
+
+import (
+	"net/http"
+	"strconv"
+
+	usecase "e-course-management/internal/email_suppression/usecase"
+	"e-course-management/internal/middleware"
+	"e-course-management/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sendgrid/sendgrid-go/helpers/eventwebhook"
+)
+
+type EmailSuppressionHandler struct {
+	usecase usecase.EmailSuppressionUseCase
+}
+
+func NewEmailSuppressionHandler(usecase usecase.EmailSuppressionUseCase) *EmailSuppressionHandler {
+	return &EmailSuppressionHandler{usecase}
+}
+
+func (handler *EmailSuppressionHandler) Route(r *gin.RouterGroup) {
+	emailSuppressionRouter := r.Group("/api/v1")
+
+	emailSuppressionRouter.POST("/webhooks/sendgrid", handler.Webhook)
+
+	emailSuppressionRouter.Use(middleware.AuthJwt, middleware.AuthAdmin)
+	{
+		emailSuppressionRouter.GET("/email_suppressions", handler.FindAll)
+		emailSuppressionRouter.DELETE("/email_suppressions/:id", handler.Delete)
+	}
+}
+
+func (handler *EmailSuppressionHandler) Webhook(ctx *gin.Context) {
+	payload, errRead := ctx.GetRawData()
+
+	if errRead != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			errRead.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	data, err := handler.usecase.HandleWebhook(
+		payload,
+		ctx.GetHeader(eventwebhook.VerificationHTTPHeader),
+		ctx.GetHeader(eventwebhook.TimestampHTTPHeader),
+	)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *EmailSuppressionHandler) FindAll(ctx *gin.Context) {
+	offset, _ := strconv.Atoi(ctx.Query("offset"))
+	limit, _ := strconv.Atoi(ctx.Query("limit"))
+
+	data := handler.usecase.FindAll(offset, limit)
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *EmailSuppressionHandler) Delete(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	err := handler.usecase.Delete(id)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		"ok",
+	))
+}
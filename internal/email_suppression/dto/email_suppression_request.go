@@ -0,0 +1,12 @@
+package email_suppression
+
+// SendgridEvent is a single entry of a SendGrid event webhook payload
+type SendgridEvent struct {
+	Email     string `json:"email"`
+	Timestamp int64  `json:"timestamp"`
+	Event     string `json:"event"`
+	Type      string `json:"type"`
+	Reason    string `json:"reason"`
+	Status    string `json:"status"`
+	SgEventID string `json:"sg_event_id"`
+}
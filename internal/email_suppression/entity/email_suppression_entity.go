@@ -0,0 +1,18 @@
+package email_suppression
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type EmailSuppression struct {
+	ID        int64          `json:"id"`
+	Email     string         `json:"email"`
+	Reason    string         `json:"reason"`
+	Detail    *string        `json:"detail"`
+	SgEventID *string        `json:"sg_event_id"`
+	CreatedAt *time.Time     `json:"created_at"`
+	UpdatedAt *time.Time     `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at"`
+}
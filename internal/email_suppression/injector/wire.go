@@ -0,0 +1,23 @@
+//go:build wireinject
+// +build wireinject
+
+package email_suppression
+
+import (
+	handler "e-course-management/internal/email_suppression/delivery/http"
+	repository "e-course-management/internal/email_suppression/repository"
+	usecase "e-course-management/internal/email_suppression/usecase"
+
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+func InitializedService(db *gorm.DB) *handler.EmailSuppressionHandler {
+	wire.Build(
+		handler.NewEmailSuppressionHandler,
+		usecase.NewEmailSuppressionUseCase,
+		repository.NewEmailSuppressionRepository,
+	)
+
+	return &handler.EmailSuppressionHandler{}
+}
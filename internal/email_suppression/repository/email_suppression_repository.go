@@ -0,0 +1,117 @@
+package email_suppression
+
+import (
+	"time"
+
+	entity "e-course-management/internal/email_suppression/entity"
+	userEntity "e-course-management/internal/user/entity"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EmailSuppressionRepository interface {
+	FindAll(offset int, limit int) []entity.EmailSuppression
+	FindOneById(id int) (*entity.EmailSuppression, *response.Error)
+	IsSuppressed(email string) bool
+	Upsert(entity entity.EmailSuppression) *response.Error
+	Delete(entity entity.EmailSuppression) *response.Error
+}
+
+type emailSuppressionRepository struct {
+	db *gorm.DB
+}
+
+// Delete implements EmailSuppressionRepository.
+// The row is removed for good so the address can be suppressed again later,
+// and the user flag is cleared with it.
+func (repository *emailSuppressionRepository) Delete(entity entity.EmailSuppression) *response.Error {
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Delete(&entity).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&userEntity.User{}).
+			Where("email = ?", entity.Email).
+			Updates(map[string]interface{}{
+				"email_suppressed_at":      nil,
+				"email_suppression_reason": nil,
+			}).Error
+	})
+
+	if err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// FindAll implements EmailSuppressionRepository.
+func (repository *emailSuppressionRepository) FindAll(offset int, limit int) []entity.EmailSuppression {
+	var emailSuppressions []entity.EmailSuppression
+
+	repository.db.Scopes(utils.Paginate(offset, limit)).Order("id DESC").Find(&emailSuppressions)
+
+	return emailSuppressions
+}
+
+// FindOneById implements EmailSuppressionRepository.
+func (repository *emailSuppressionRepository) FindOneById(id int) (*entity.EmailSuppression, *response.Error) {
+	var emailSuppression entity.EmailSuppression
+
+	if err := repository.db.First(&emailSuppression, id).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &emailSuppression, nil
+}
+
+// IsSuppressed implements EmailSuppressionRepository.
+func (repository *emailSuppressionRepository) IsSuppressed(email string) bool {
+	var count int64
+
+	repository.db.Model(&entity.EmailSuppression{}).Where("email = ?", email).Count(&count)
+
+	return count > 0
+}
+
+// Upsert implements EmailSuppressionRepository.
+// The latest event wins and the matching user is flagged with its reason.
+func (repository *emailSuppressionRepository) Upsert(entity entity.EmailSuppression) *response.Error {
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "email"}},
+			DoUpdates: clause.AssignmentColumns([]string{"reason", "detail", "sg_event_id", "updated_at"}),
+		}).Create(&entity).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&userEntity.User{}).
+			Where("email = ?", entity.Email).
+			Updates(map[string]interface{}{
+				"email_suppressed_at":      time.Now(),
+				"email_suppression_reason": entity.Reason,
+			}).Error
+	})
+
+	if err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+func NewEmailSuppressionRepository(db *gorm.DB) EmailSuppressionRepository {
+	return &emailSuppressionRepository{db}
+}
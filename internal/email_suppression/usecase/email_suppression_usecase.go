@@ -0,0 +1,153 @@
+package email_suppression
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	dto "e-course-management/internal/email_suppression/dto"
+	entity "e-course-management/internal/email_suppression/entity"
+	repository "e-course-management/internal/email_suppression/repository"
+	"e-course-management/pkg/response"
+
+	"github.com/sendgrid/sendgrid-go/helpers/eventwebhook"
+)
+
+// webhookTolerance is how far the signed timestamp of a webhook may be from
+// now, older requests are treated as replays
+const webhookTolerance = 5 * time.Minute
+
+type EmailSuppressionUseCase interface {
+	FindAll(offset int, limit int) []entity.EmailSuppression
+	Delete(id int) *response.Error
+	HandleWebhook(payload []byte, signature string, timestamp string) (int, *response.Error)
+}
+
+type emailSuppressionUseCase struct {
+	repository repository.EmailSuppressionRepository
+}
+
+// Delete implements EmailSuppressionUseCase.
+func (usecase *emailSuppressionUseCase) Delete(id int) *response.Error {
+	emailSuppression, err := usecase.repository.FindOneById(id)
+
+	if err != nil {
+		return err
+	}
+
+	return usecase.repository.Delete(*emailSuppression)
+}
+
+// FindAll implements EmailSuppressionUseCase.
+func (usecase *emailSuppressionUseCase) FindAll(offset int, limit int) []entity.EmailSuppression {
+	return usecase.repository.FindAll(offset, limit)
+}
+
+// HandleWebhook implements EmailSuppressionUseCase.
+// It verifies the SendGrid signature, suppresses the addresses of bounce,
+// dropped, spamreport and unsubscribe events and returns how many were recorded.
+func (usecase *emailSuppressionUseCase) HandleWebhook(payload []byte, signature string, timestamp string) (int, *response.Error) {
+	if err := verifySignature(payload, signature, timestamp); err != nil {
+		return 0, err
+	}
+
+	var events []dto.SendgridEvent
+
+	if err := json.Unmarshal(payload, &events); err != nil {
+		return 0, &response.Error{
+			Code: 400,
+			Err:  err,
+		}
+	}
+
+	recorded := 0
+
+	for _, event := range events {
+		if !suppresses(event) {
+			continue
+		}
+
+		emailSuppression := entity.EmailSuppression{
+			Email:  strings.ToLower(event.Email),
+			Reason: event.Event,
+		}
+
+		if detail := strings.TrimSpace(event.Reason); detail != "" {
+			emailSuppression.Detail = &detail
+		}
+
+		if event.SgEventID != "" {
+			emailSuppression.SgEventID = &event.SgEventID
+		}
+
+		if err := usecase.repository.Upsert(emailSuppression); err != nil {
+			return recorded, err
+		}
+
+		recorded++
+	}
+
+	return recorded, nil
+}
+
+// suppresses reports whether an event means we should stop emailing the
+// address. Blocked bounces are temporary and are left to the outbox retries.
+func suppresses(event dto.SendgridEvent) bool {
+	if event.Email == "" {
+		return false
+	}
+
+	switch event.Event {
+	case "bounce":
+		return event.Type != "blocked"
+	case "dropped", "spamreport", "unsubscribe":
+		return true
+	default:
+		return false
+	}
+}
+
+func verifySignature(payload []byte, signature string, timestamp string) *response.Error {
+	publicKey, err := eventwebhook.ConvertPublicKeyBase64ToECDSA(os.Getenv("SENDGRID_WEBHOOK_PUBLIC_KEY"))
+
+	if err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  errors.New("sendgrid webhook public key is not configured"),
+		}
+	}
+
+	valid, err := eventwebhook.VerifySignature(publicKey, payload, signature, timestamp)
+
+	if err != nil || !valid {
+		return &response.Error{
+			Code: 401,
+			Err:  errors.New("invalid webhook signature"),
+		}
+	}
+
+	signedAt, err := strconv.ParseInt(timestamp, 10, 64)
+
+	if err != nil {
+		return &response.Error{
+			Code: 401,
+			Err:  errors.New("invalid webhook timestamp"),
+		}
+	}
+
+	if age := time.Since(time.Unix(signedAt, 0)); age > webhookTolerance || age < -webhookTolerance {
+		return &response.Error{
+			Code: 401,
+			Err:  errors.New("webhook timestamp is too old or too far in the future"),
+		}
+	}
+
+	return nil
+}
+
+func NewEmailSuppressionUseCase(repository repository.EmailSuppressionRepository) EmailSuppressionUseCase {
+	return &emailSuppressionUseCase{repository}
+}
@@ -0,0 +1,139 @@
+package email_template
+
+import (
+	"net/http"
+
+	dto "e-course-management/internal/email_template/dto"
+	usecase "e-course-management/internal/email_template/usecase"
+	"e-course-management/internal/middleware"
+	"e-course-management/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EmailTemplateHandler struct {
+	usecase usecase.EmailTemplateUseCase
+}
+
+func NewEmailTemplateHandler(usecase usecase.EmailTemplateUseCase) *EmailTemplateHandler {
+	return &EmailTemplateHandler{usecase}
+}
+
+func (handler *EmailTemplateHandler) Route(r *gin.RouterGroup) {
+	emailTemplateRouter := r.Group("/api/v1")
+
+	emailTemplateRouter.Use(middleware.AuthJwt, middleware.AuthAdmin)
+	{
+		emailTemplateRouter.GET("/email_templates", handler.FindAll)
+		emailTemplateRouter.GET("/email_templates/:name/preview", handler.PreviewSample)
+		emailTemplateRouter.POST("/email_templates/:name/preview", handler.Preview)
+		emailTemplateRouter.POST("/email_templates/:name/test", handler.SendTest)
+	}
+}
+
+func (handler *EmailTemplateHandler) FindAll(ctx *gin.Context) {
+	data := handler.usecase.FindAll()
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+// PreviewSample renders the template with its sample data. With ?format=html or
+// ?format=text the body is returned as is so it can be opened in a browser.
+func (handler *EmailTemplateHandler) PreviewSample(ctx *gin.Context) {
+	input := dto.EmailTemplatePreviewRequestBody{
+		Locale: ctx.Query("locale"),
+	}
+
+	data, err := handler.usecase.Preview(ctx.Param("name"), input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	switch ctx.Query("format") {
+	case "html":
+		ctx.Data(http.StatusOK, "text/html; charset=utf-8", []byte(data.Html))
+	case "text":
+		ctx.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(data.Text))
+	default:
+		ctx.JSON(http.StatusOK, response.Response(
+			http.StatusOK,
+			http.StatusText(http.StatusOK),
+			data,
+		))
+	}
+}
+
+func (handler *EmailTemplateHandler) Preview(ctx *gin.Context) {
+	var input dto.EmailTemplatePreviewRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	data, err := handler.usecase.Preview(ctx.Param("name"), input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *EmailTemplateHandler) SendTest(ctx *gin.Context) {
+	var input dto.EmailTemplateTestRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	data, err := handler.usecase.SendTest(ctx.Param("name"), input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
@@ -0,0 +1,12 @@
+package email_template
+
+type EmailTemplatePreviewRequestBody struct {
+	Locale string                 `json:"locale"`
+	Data   map[string]interface{} `json:"data"`
+}
+
+type EmailTemplateTestRequestBody struct {
+	Email  string                 `json:"email" binding:"email"`
+	Locale string                 `json:"locale"`
+	Data   map[string]interface{} `json:"data"`
+}
@@ -0,0 +1,22 @@
+//go:build wireinject
+// +build wireinject
+
+package email_template
+
+import (
+	handler "e-course-management/internal/email_template/delivery/http"
+	usecase "e-course-management/internal/email_template/usecase"
+	mail "e-course-management/pkg/mail/sendgrid"
+
+	"github.com/google/wire"
+)
+
+func InitializedService() *handler.EmailTemplateHandler {
+	wire.Build(
+		handler.NewEmailTemplateHandler,
+		usecase.NewEmailTemplateUseCase,
+		mail.NewTransport,
+	)
+
+	return &handler.EmailTemplateHandler{}
+}
@@ -0,0 +1,93 @@
+package email_template
+
+import (
+	"errors"
+
+	dto "e-course-management/internal/email_template/dto"
+	mail "e-course-management/pkg/mail/sendgrid"
+	"e-course-management/pkg/response"
+)
+
+type EmailTemplateUseCase interface {
+	FindAll() []mail.TemplateInfo
+	Preview(name string, dto dto.EmailTemplatePreviewRequestBody) (*mail.RenderedEmail, *response.Error)
+	SendTest(name string, dto dto.EmailTemplateTestRequestBody) (*mail.RenderedEmail, *response.Error)
+}
+
+type emailTemplateUseCase struct {
+	transport mail.Transport
+}
+
+// FindAll implements EmailTemplateUseCase.
+func (usecase *emailTemplateUseCase) FindAll() []mail.TemplateInfo {
+	return mail.ListTemplates()
+}
+
+// Preview implements EmailTemplateUseCase.
+// Data falls back to the template's sample data when none is supplied.
+func (usecase *emailTemplateUseCase) Preview(name string, dto dto.EmailTemplatePreviewRequestBody) (*mail.RenderedEmail, *response.Error) {
+	if !mail.TemplateExists(name) {
+		return nil, &response.Error{
+			Code: 404,
+			Err:  errors.New("email template not found"),
+		}
+	}
+
+	data := dto.Data
+
+	if data == nil {
+		sample, err := mail.SampleData(name)
+
+		if err != nil {
+			return nil, &response.Error{
+				Code: 400,
+				Err:  err,
+			}
+		}
+
+		data = sample
+	}
+
+	rendered, err := mail.ParseTemplate(name, dto.Locale, data)
+
+	if err != nil {
+		return nil, &response.Error{
+			Code: 400,
+			Err:  err,
+		}
+	}
+
+	return rendered, nil
+}
+
+// SendTest implements EmailTemplateUseCase.
+// The test copy bypasses the outbox so delivery problems surface immediately.
+func (usecase *emailTemplateUseCase) SendTest(name string, dto dto.EmailTemplateTestRequestBody) (*mail.RenderedEmail, *response.Error) {
+	rendered, err := usecase.Preview(name, emailTemplatePreview(dto))
+
+	if err != nil {
+		return nil, err
+	}
+
+	rendered.Subject = "[TEST] " + rendered.Subject
+
+	if errSend := usecase.transport.Send(dto.Email, rendered.Subject, rendered.Html, rendered.Text); errSend != nil {
+		return nil, &response.Error{
+			Code: 502,
+			Err:  errSend,
+		}
+	}
+
+	return rendered, nil
+}
+
+func emailTemplatePreview(test dto.EmailTemplateTestRequestBody) dto.EmailTemplatePreviewRequestBody {
+	return dto.EmailTemplatePreviewRequestBody{
+		Locale: test.Locale,
+		Data:   test.Data,
+	}
+}
+
+func NewEmailTemplateUseCase(transport mail.Transport) EmailTemplateUseCase {
+	return &emailTemplateUseCase{transport}
+}
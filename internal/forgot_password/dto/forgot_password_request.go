@@ -19,7 +19,6 @@ type ForgotPasswordCreateRequestBody struct {
 }
 
 type ForgotPasswordEmailRequestBody struct {
-	SUBJECT string
 	EMAIL   string
 	CODE    string
 }
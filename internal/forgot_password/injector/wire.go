@@ -6,6 +6,8 @@ package forgot_password
 import (
 	"github.com/google/wire"
 	"gorm.io/gorm"
+	emailOutboxRepository "e-course-management/internal/email_outbox/repository"
+	emailSuppressionRepository "e-course-management/internal/email_suppression/repository"
 	handler "e-course-management/internal/forgot_password/delivery/http"
 	repository "e-course-management/internal/forgot_password/repository"
 	usecase "e-course-management/internal/forgot_password/usecase"
@@ -22,6 +24,8 @@ func InitializedService(db *gorm.DB) *handler.ForgotPasswordHandler {
 		userRepository.NewUserRepository,
 		userUseCase.NewUserUseCase,
 		mail.NewMailUseCase,
+		emailOutboxRepository.NewEmailOutboxRepository,
+		emailSuppressionRepository.NewEmailSuppressionRepository,
 	)
 	return &handler.ForgotPasswordHandler{}
 }
\ No newline at end of file
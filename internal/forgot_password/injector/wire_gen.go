@@ -7,6 +7,8 @@
 package forgot_password
 
 import (
+	"e-course-management/internal/email_outbox/repository"
+	"e-course-management/internal/email_suppression/repository"
 	"e-course-management/internal/forgot_password/delivery/http"
 	forgot_password2 "e-course-management/internal/forgot_password/repository"
 	forgot_password3 "e-course-management/internal/forgot_password/usecase"
@@ -22,8 +24,10 @@ func InitializedService(db *gorm.DB) *forgot_password.ForgotPasswordHandler {
 	forgotPasswordRepository := forgot_password2.NewForgotPasswordRepository(db)
 	userRepository := user.NewUserRepository(db)
 	userUseCase := user2.NewUserUseCase(userRepository)
-	mailMail := mail.NewMailUseCase()
-	forgotPasswordUseCase := forgot_password3.NewForgotPasswordUseCase(forgotPasswordRepository, userUseCase, mailMail)
+	emailOutboxRepository := email_outbox.NewEmailOutboxRepository(db)
+	emailSuppressionRepository := email_suppression.NewEmailSuppressionRepository(db)
+	mailMail := mail.NewMailUseCase(emailOutboxRepository, emailSuppressionRepository)
+	forgotPasswordUseCase := forgot_password3.NewForgotPasswordUseCase(db, forgotPasswordRepository, userUseCase, mailMail)
 	forgotPasswordHandler := forgot_password.NewForgotPasswordHandler(forgotPasswordUseCase)
 	return forgotPasswordHandler
 }
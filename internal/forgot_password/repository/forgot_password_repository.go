@@ -11,6 +11,7 @@ type ForgotPasswordRepository interface {
 	Create(entity entity.ForgotPassword) (*entity.ForgotPassword, *response.Error)
 	FindOneByCode(code string) (*entity.ForgotPassword, *response.Error)
 	Update(entity entity.ForgotPassword) (*entity.ForgotPassword, *response.Error)
+	WithTx(tx *gorm.DB) ForgotPasswordRepository
 }
 
 type forgotPasswordRepository struct {
@@ -55,6 +56,11 @@ func (repository *forgotPasswordRepository) Update(entity entity.ForgotPassword)
 	return &entity, nil
 }
 
+// WithTx implements ForgotPasswordRepository.
+func (repository *forgotPasswordRepository) WithTx(tx *gorm.DB) ForgotPasswordRepository {
+	return &forgotPasswordRepository{tx}
+}
+
 func NewForgotPasswordRepository(db *gorm.DB) ForgotPasswordRepository {
 	return &forgotPasswordRepository{db}
 }
@@ -11,6 +11,8 @@ import (
 	"e-course-management/pkg/utils"
 	"errors"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type ForgotPasswordUseCase interface {
@@ -19,6 +21,7 @@ type ForgotPasswordUseCase interface {
 }
 
 type forgotPasswordUseCase struct {
+	db          *gorm.DB
 	repository  repository.ForgotPasswordRepository
 	userUseCase userUseCase.UserUseCase
 	mail        mail.Mail
@@ -49,19 +52,32 @@ func (usecase *forgotPasswordUseCase) Create(dtoForgotPassword dto.ForgotPasswor
 		ExpiredAt: &dateTime,
 	}
 
-	dataForgotPassword, err := usecase.repository.Create(forgotPassword)
+	var dataForgotPassword *entity.ForgotPassword
 
-	// Send email
-	dataEmailForgotPassword := dto.ForgotPasswordEmailRequestBody{
-		SUBJECT: "Code Forgot Password",
-		EMAIL:   user.Email,
-		CODE:    forgotPassword.Code,
-	}
+	// Kode dan email disimpan dalam satu transaksi
+	errTx := usecase.db.Transaction(func(tx *gorm.DB) error {
+		var err *response.Error
 
-	go usecase.mail.SendForgotPassword(user.Email, dataEmailForgotPassword)
+		dataForgotPassword, err = usecase.repository.WithTx(tx).Create(forgotPassword)
 
-	if err != nil {
-		return nil, err
+		if err != nil {
+			return err
+		}
+
+		dataEmailForgotPassword := dto.ForgotPasswordEmailRequestBody{
+			EMAIL: user.Email,
+			CODE:  forgotPassword.Code,
+		}
+
+		if err := usecase.mail.WithTx(tx).SendForgotPassword(user.Email, user.Locale, dataEmailForgotPassword); err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	if errTx != nil {
+		return nil, response.FromError(errTx)
 	}
 
 	return dataForgotPassword, nil
@@ -104,11 +120,12 @@ func (usecase *forgotPasswordUseCase) Update(dto dto.ForgotPasswordUpdateRequest
 }
 
 func NewForgotPasswordUseCase(
+	db *gorm.DB,
 	repository repository.ForgotPasswordRepository,
 	userUseCase userUseCase.UserUseCase,
 	mail mail.Mail,
 ) ForgotPasswordUseCase {
 	return &forgotPasswordUseCase{
-		repository, userUseCase, mail,
+		db, repository, userUseCase, mail,
 	}
 }
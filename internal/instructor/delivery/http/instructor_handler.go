@@ -0,0 +1,648 @@
+package instructor
+
+import (
+	"net/http"
+	"strconv"
+
+	curriculumDto "e-course-management/internal/curriculum/dto"
+	dto "e-course-management/internal/instructor/dto"
+	usecase "e-course-management/internal/instructor/usecase"
+	"e-course-management/internal/middleware"
+	productDto "e-course-management/internal/product/dto"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InstructorHandler struct {
+	usecase usecase.InstructorUseCase
+}
+
+func NewInstructorHandler(usecase usecase.InstructorUseCase) *InstructorHandler {
+	return &InstructorHandler{usecase}
+}
+
+func (handler *InstructorHandler) Route(r *gin.RouterGroup) {
+	instructorRouter := r.Group("/api/v1")
+
+	instructorRouter.GET("/products/:id/instructors", handler.FindAllByProduct)
+
+	// Endpoints of the signed in instructor, limited to the courses assigned to them
+	workspaceRouter := instructorRouter.Group("/instructor", middleware.AuthJwt, middleware.AuthUser)
+	{
+		workspaceRouter.GET("/profile", handler.FindMe)
+		workspaceRouter.GET("/dashboard", handler.Dashboard)
+		workspaceRouter.GET("/enrollments", handler.FindEnrollments)
+		workspaceRouter.GET("/products", handler.FindMyProducts)
+		workspaceRouter.PATCH("/products/:id", handler.UpdateProduct)
+		workspaceRouter.POST("/products/:id/sections", handler.CreateSection)
+		workspaceRouter.PUT("/products/:id/sections/order", handler.ReorderSections)
+		workspaceRouter.PATCH("/sections/:id", handler.UpdateSection)
+		workspaceRouter.DELETE("/sections/:id", handler.DeleteSection)
+		workspaceRouter.POST("/sections/:id/lessons", handler.CreateLesson)
+		workspaceRouter.PUT("/sections/:id/lessons/order", handler.ReorderLessons)
+		workspaceRouter.PATCH("/lessons/:id", handler.UpdateLesson)
+		workspaceRouter.DELETE("/lessons/:id", handler.DeleteLesson)
+	}
+
+	instructorRouter.Use(middleware.AuthJwt, middleware.AuthAdmin)
+	{
+		instructorRouter.GET("/instructors", handler.FindAll)
+		instructorRouter.GET("/instructors/:id", handler.FindById)
+		instructorRouter.POST("/instructors", handler.Create)
+		instructorRouter.PATCH("/instructors/:id", handler.Update)
+		instructorRouter.DELETE("/instructors/:id", handler.Delete)
+		instructorRouter.PUT("/products/:id/instructors", handler.AssignProduct)
+	}
+}
+
+func (handler *InstructorHandler) FindAllByProduct(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	data, err := handler.usecase.FindAllByProduct(id)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *InstructorHandler) FindMe(ctx *gin.Context) {
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.FindMe(user.ID)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *InstructorHandler) Dashboard(ctx *gin.Context) {
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.Dashboard(user.ID)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *InstructorHandler) FindEnrollments(ctx *gin.Context) {
+	var filter dto.InstructorEnrollmentFilterRequest
+
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.FindEnrollments(user.ID, filter)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *InstructorHandler) FindMyProducts(ctx *gin.Context) {
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.FindMyProducts(user.ID)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *InstructorHandler) UpdateProduct(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input productDto.ProductRequestBody
+
+	if err := ctx.ShouldBind(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.UpdateProduct(user.ID, id, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *InstructorHandler) CreateSection(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input curriculumDto.SectionRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.CreateSection(user.ID, id, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Response(
+		http.StatusCreated,
+		http.StatusText(http.StatusCreated),
+		data,
+	))
+}
+
+func (handler *InstructorHandler) ReorderSections(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input curriculumDto.ReorderRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.ReorderSections(user.ID, id, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *InstructorHandler) UpdateSection(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input curriculumDto.SectionRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.UpdateSection(user.ID, id, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *InstructorHandler) DeleteSection(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	user := utils.GetCurrentUser(ctx)
+
+	err := handler.usecase.DeleteSection(user.ID, id)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		"ok",
+	))
+}
+
+func (handler *InstructorHandler) CreateLesson(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input curriculumDto.LessonRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.CreateLesson(user.ID, id, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Response(
+		http.StatusCreated,
+		http.StatusText(http.StatusCreated),
+		data,
+	))
+}
+
+func (handler *InstructorHandler) ReorderLessons(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input curriculumDto.ReorderRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.ReorderLessons(user.ID, id, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *InstructorHandler) UpdateLesson(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input curriculumDto.LessonRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.UpdateLesson(user.ID, id, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *InstructorHandler) DeleteLesson(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	user := utils.GetCurrentUser(ctx)
+
+	err := handler.usecase.DeleteLesson(user.ID, id)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		"ok",
+	))
+}
+
+func (handler *InstructorHandler) FindAll(ctx *gin.Context) {
+	offset, _ := strconv.Atoi(ctx.Query("offset"))
+	limit, _ := strconv.Atoi(ctx.Query("limit"))
+
+	data := handler.usecase.FindAll(offset, limit)
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *InstructorHandler) FindById(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	data, err := handler.usecase.FindOneById(id)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *InstructorHandler) Create(ctx *gin.Context) {
+	var input dto.InstructorRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+	input.CreatedBy = &user.ID
+
+	data, err := handler.usecase.Create(input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Response(
+		http.StatusCreated,
+		http.StatusText(http.StatusCreated),
+		data,
+	))
+}
+
+func (handler *InstructorHandler) Update(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input dto.InstructorUpdateRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+	input.UpdatedBy = &user.ID
+
+	data, err := handler.usecase.Update(id, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *InstructorHandler) Delete(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	user := utils.GetCurrentUser(ctx)
+
+	err := handler.usecase.Delete(id, user.ID)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		"ok",
+	))
+}
+
+func (handler *InstructorHandler) AssignProduct(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input dto.ProductInstructorRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+	input.CreatedBy = &user.ID
+
+	data, err := handler.usecase.AssignProduct(id, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
@@ -0,0 +1,26 @@
+package instructor
+
+type InstructorRequestBody struct {
+	UserID    int64   `json:"user_id" binding:"required"`
+	Headline  *string `json:"headline"`
+	Bio       *string `json:"bio"`
+	CreatedBy *int64  `json:"-"`
+}
+
+type InstructorUpdateRequestBody struct {
+	Headline  *string `json:"headline"`
+	Bio       *string `json:"bio"`
+	UpdatedBy *int64  `json:"-"`
+}
+
+// ProductInstructorRequestBody replaces the instructors of a product
+type ProductInstructorRequestBody struct {
+	InstructorIDs []int64 `json:"instructor_ids" binding:"required"`
+	CreatedBy     *int64  `json:"-"`
+}
+
+type InstructorEnrollmentFilterRequest struct {
+	Offset    int    `form:"offset"`
+	Limit     int    `form:"limit"`
+	ProductID *int64 `form:"product_id"`
+}
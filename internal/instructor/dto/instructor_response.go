@@ -0,0 +1,53 @@
+package instructor
+
+import "time"
+
+// InstructorProfileResponse is the public credit of an instructor on a course
+type InstructorProfileResponse struct {
+	ID       int64   `json:"id"`
+	Name     string  `json:"name"`
+	Headline *string `json:"headline"`
+	Bio      *string `json:"bio"`
+}
+
+// InstructorCourseStats sums up one course of the instructor. Revenue counts
+// paid orders only, with order discounts spread over their items.
+type InstructorCourseStats struct {
+	ProductID     int64   `json:"product_id"`
+	Title         string  `json:"title"`
+	Price         int64   `json:"price"`
+	AverageRating float64 `json:"average_rating"`
+	ReviewCount   int     `json:"review_count"`
+	Enrollments   int64   `json:"enrollments"`
+	Completions   int64   `json:"completions"`
+	Sales         int64   `json:"sales"`
+	Revenue       int64   `json:"revenue"`
+}
+
+type InstructorDashboardResponse struct {
+	Instructor       InstructorProfileResponse `json:"instructor"`
+	TotalCourses     int                       `json:"total_courses"`
+	TotalEnrollments int64                     `json:"total_enrollments"`
+	TotalCompletions int64                     `json:"total_completions"`
+	TotalSales       int64                     `json:"total_sales"`
+	TotalRevenue     int64                     `json:"total_revenue"`
+	Courses          []InstructorCourseStats   `json:"courses"`
+}
+
+type InstructorEnrollment struct {
+	ClassRoomID  int64      `json:"class_room_id"`
+	ProductID    int64      `json:"product_id"`
+	ProductTitle string     `json:"product_title"`
+	UserID       int64      `json:"user_id"`
+	UserName     string     `json:"user_name"`
+	Progress     int        `json:"progress"`
+	CompletedAt  *time.Time `json:"completed_at"`
+	EnrolledAt   *time.Time `json:"enrolled_at"`
+}
+
+type InstructorEnrollmentListResponse struct {
+	Enrollments []InstructorEnrollment `json:"enrollments"`
+	Total       int64                  `json:"total"`
+	Offset      int                    `json:"offset"`
+	Limit       int                    `json:"limit"`
+}
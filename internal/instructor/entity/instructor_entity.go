@@ -0,0 +1,34 @@
+package instructor
+
+import (
+	admin "e-course-management/internal/admin/entity"
+	product "e-course-management/internal/product/entity"
+	user "e-course-management/internal/user/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Instructor is the teaching profile of a user account
+type Instructor struct {
+	ID          int64             `json:"id"`
+	UserID      int64             `json:"user_id"`
+	User        *user.User        `json:"user,omitempty" gorm:"foreignKey:UserID;references:ID"`
+	Headline    *string           `json:"headline"`
+	Bio         *string           `json:"bio"`
+	Products    []product.Product `json:"products,omitempty" gorm:"many2many:product_instructors"`
+	CreatedByID *int64            `json:"created_by" gorm:"column:created_by"`
+	CreatedBy   *admin.Admin      `json:"-" gorm:"foreignKey:CreatedByID;references:ID"`
+	UpdatedByID *int64            `json:"updated_by" gorm:"column:updated_by"`
+	UpdatedBy   *admin.Admin      `json:"-" gorm:"foreignKey:UpdatedByID;references:ID"`
+	CreatedAt   *time.Time        `json:"created_at"`
+	UpdatedAt   *time.Time        `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt    `json:"deleted_at"`
+}
+
+type ProductInstructor struct {
+	ProductID    int64      `json:"product_id" gorm:"primaryKey"`
+	InstructorID int64      `json:"instructor_id" gorm:"primaryKey"`
+	CreatedByID  *int64     `json:"created_by" gorm:"column:created_by"`
+	CreatedAt    *time.Time `json:"created_at"`
+}
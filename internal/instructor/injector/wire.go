@@ -0,0 +1,49 @@
+//go:build wireinject
+// +build wireinject
+
+package instructor
+
+import (
+	classRoomRepository "e-course-management/internal/class_room/repository"
+	classRoomUseCase "e-course-management/internal/class_room/usecase"
+	curriculumRepository "e-course-management/internal/curriculum/repository"
+	curriculumUseCase "e-course-management/internal/curriculum/usecase"
+	handler "e-course-management/internal/instructor/delivery/http"
+	repository "e-course-management/internal/instructor/repository"
+	usecase "e-course-management/internal/instructor/usecase"
+	mediaRepository "e-course-management/internal/media/repository"
+	mediaUseCase "e-course-management/internal/media/usecase"
+	productRepository "e-course-management/internal/product/repository"
+	productUseCase "e-course-management/internal/product/usecase"
+	productCategoryRepository "e-course-management/internal/product_category/repository"
+	productCategoryUseCase "e-course-management/internal/product_category/usecase"
+	userRepository "e-course-management/internal/user/repository"
+	userUseCase "e-course-management/internal/user/usecase"
+	"e-course-management/pkg/storage"
+
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+func InitializedService(db *gorm.DB) *handler.InstructorHandler {
+	wire.Build(
+		handler.NewInstructorHandler,
+		usecase.NewInstructorUseCase,
+		repository.NewInstructorRepository,
+		userUseCase.NewUserUseCase,
+		userRepository.NewUserRepository,
+		curriculumUseCase.NewCurriculumUseCase,
+		curriculumRepository.NewCurriculumRepository,
+		productUseCase.NewProductUseCase,
+		productRepository.NewProductRepository,
+		productCategoryUseCase.NewProductCategoryUseCase,
+		productCategoryRepository.NewProductCategoryRepository,
+		mediaUseCase.NewMediaUseCase,
+		mediaRepository.NewMediaRepository,
+		storage.NewStorage,
+		classRoomUseCase.NewClassRoomUseCase,
+		classRoomRepository.NewClassRoomRepository,
+	)
+
+	return &handler.InstructorHandler{}
+}
@@ -0,0 +1,361 @@
+package instructor
+
+import (
+	"errors"
+
+	classRoomEntity "e-course-management/internal/class_room/entity"
+	curriculumEntity "e-course-management/internal/curriculum/entity"
+	dto "e-course-management/internal/instructor/dto"
+	entity "e-course-management/internal/instructor/entity"
+	orderEntity "e-course-management/internal/order/entity"
+	productEntity "e-course-management/internal/product/entity"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+type InstructorRepository interface {
+	FindAll(offset int, limit int) []entity.Instructor
+	FindAllByProductId(productID int64) []entity.Instructor
+	FindOneById(id int) (*entity.Instructor, *response.Error)
+	FindOneByUserId(userID int64) (*entity.Instructor, *response.Error)
+	FindTrashedByUserId(userID int64) *entity.Instructor
+	CountByIds(ids []int64) int64
+	Create(instructor entity.Instructor) (*entity.Instructor, *response.Error)
+	Update(instructor entity.Instructor) (*entity.Instructor, *response.Error)
+	Restore(instructor entity.Instructor) (*entity.Instructor, *response.Error)
+	Delete(instructor entity.Instructor) *response.Error
+	ReplaceProductInstructors(productID int64, instructorIDs []int64, createdBy *int64) *response.Error
+	IsAssigned(instructorID int64, productID int64) bool
+	FindProductsByInstructorId(instructorID int64) []productEntity.Product
+	FindProductIdBySectionId(sectionID int) (int64, *response.Error)
+	FindProductIdByLessonId(lessonID int) (int64, *response.Error)
+	FindCourseStats(instructorID int64) []dto.InstructorCourseStats
+	FindEnrollments(instructorID int64, filter dto.InstructorEnrollmentFilterRequest) ([]classRoomEntity.ClassRoom, int64)
+}
+
+type instructorRepository struct {
+	db *gorm.DB
+}
+
+// CountByIds implements InstructorRepository.
+func (repository *instructorRepository) CountByIds(ids []int64) int64 {
+	var count int64
+
+	repository.db.Model(&entity.Instructor{}).Where("id IN ?", ids).Count(&count)
+
+	return count
+}
+
+// Create implements InstructorRepository.
+func (repository *instructorRepository) Create(instructor entity.Instructor) (*entity.Instructor, *response.Error) {
+	if err := repository.db.Create(&instructor).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &instructor, nil
+}
+
+// Delete implements InstructorRepository.
+// The instructor is unassigned from every product.
+func (repository *instructorRepository) Delete(instructor entity.Instructor) *response.Error {
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("instructor_id = ?", instructor.ID).Delete(&entity.ProductInstructor{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&instructor).Update("updated_by", instructor.UpdatedByID).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&instructor).Error
+	})
+
+	if err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// FindAll implements InstructorRepository.
+func (repository *instructorRepository) FindAll(offset int, limit int) []entity.Instructor {
+	var instructors []entity.Instructor
+
+	repository.db.
+		Scopes(utils.Paginate(offset, limit)).
+		Preload("User").
+		Order("id DESC").
+		Find(&instructors)
+
+	return instructors
+}
+
+// FindAllByProductId implements InstructorRepository.
+func (repository *instructorRepository) FindAllByProductId(productID int64) []entity.Instructor {
+	var instructors []entity.Instructor
+
+	repository.db.
+		Preload("User").
+		Joins("JOIN product_instructors ON product_instructors.instructor_id = instructors.id").
+		Where("product_instructors.product_id = ?", productID).
+		Order("product_instructors.created_at, instructors.id").
+		Find(&instructors)
+
+	return instructors
+}
+
+// FindCourseStats implements InstructorRepository.
+// Order discounts are spread over the order items in proportion to their
+// price, so the revenue of a course is what was actually paid for it.
+func (repository *instructorRepository) FindCourseStats(instructorID int64) []dto.InstructorCourseStats {
+	var stats []dto.InstructorCourseStats
+
+	repository.db.Model(&productEntity.Product{}).
+		Select(
+			"products.id AS product_id, products.title, products.price, products.average_rating, products.review_count, "+
+				"(SELECT COUNT(*) FROM class_rooms WHERE class_rooms.product_id = products.id AND class_rooms.deleted_at IS NULL) AS enrollments, "+
+				"(SELECT COUNT(*) FROM class_rooms WHERE class_rooms.product_id = products.id AND class_rooms.deleted_at IS NULL AND class_rooms.completed_at IS NOT NULL) AS completions, "+
+				"(SELECT COUNT(*) FROM order_details JOIN orders ON orders.id = order_details.order_id "+
+				"WHERE order_details.product_id = products.id AND orders.status = ? AND orders.deleted_at IS NULL AND order_details.deleted_at IS NULL) AS sales, "+
+				"(SELECT CAST(COALESCE(ROUND(SUM(CASE WHEN orders.price > 0 THEN order_details.price * orders.total_price / orders.price ELSE 0 END)), 0) AS SIGNED) "+
+				"FROM order_details JOIN orders ON orders.id = order_details.order_id "+
+				"WHERE order_details.product_id = products.id AND orders.status = ? AND orders.deleted_at IS NULL AND order_details.deleted_at IS NULL) AS revenue",
+			orderEntity.StatusPaid, orderEntity.StatusPaid,
+		).
+		Joins("JOIN product_instructors ON product_instructors.product_id = products.id").
+		Where("product_instructors.instructor_id = ?", instructorID).
+		Order("products.id").
+		Scan(&stats)
+
+	return stats
+}
+
+// FindEnrollments implements InstructorRepository.
+// It returns one page of enrollments in the courses of the instructor, newest
+// first, and the total match count.
+func (repository *instructorRepository) FindEnrollments(instructorID int64, filter dto.InstructorEnrollmentFilterRequest) ([]classRoomEntity.ClassRoom, int64) {
+	var classRooms []classRoomEntity.ClassRoom
+	var total int64
+
+	query := repository.db.Model(&classRoomEntity.ClassRoom{}).
+		Joins("JOIN product_instructors ON product_instructors.product_id = class_rooms.product_id").
+		Where("product_instructors.instructor_id = ?", instructorID)
+
+	if filter.ProductID != nil {
+		query = query.Where("class_rooms.product_id = ?", *filter.ProductID)
+	}
+
+	query = query.Session(&gorm.Session{})
+
+	query.Count(&total)
+
+	query.Preload("User").
+		Preload("Product").
+		Scopes(utils.Paginate(filter.Offset, filter.Limit)).
+		Order("class_rooms.created_at DESC, class_rooms.id DESC").
+		Find(&classRooms)
+
+	return classRooms, total
+}
+
+// FindOneById implements InstructorRepository.
+func (repository *instructorRepository) FindOneById(id int) (*entity.Instructor, *response.Error) {
+	var instructor entity.Instructor
+
+	if err := repository.db.Preload("User").Preload("Products").First(&instructor, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("instructor not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &instructor, nil
+}
+
+// FindOneByUserId implements InstructorRepository.
+func (repository *instructorRepository) FindOneByUserId(userID int64) (*entity.Instructor, *response.Error) {
+	var instructor entity.Instructor
+
+	if err := repository.db.Preload("User").Where("user_id = ?", userID).First(&instructor).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("instructor not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &instructor, nil
+}
+
+// FindProductIdByLessonId implements InstructorRepository.
+func (repository *instructorRepository) FindProductIdByLessonId(lessonID int) (int64, *response.Error) {
+	var lesson curriculumEntity.Lesson
+
+	if err := repository.db.Preload("Section").First(&lesson, lessonID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, &response.Error{
+				Code: 404,
+				Err:  errors.New("lesson not found"),
+			}
+		}
+
+		return 0, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	// the section of the lesson has been deleted
+	if lesson.Section == nil {
+		return 0, &response.Error{
+			Code: 404,
+			Err:  errors.New("lesson not found"),
+		}
+	}
+
+	return lesson.Section.ProductID, nil
+}
+
+// FindProductIdBySectionId implements InstructorRepository.
+func (repository *instructorRepository) FindProductIdBySectionId(sectionID int) (int64, *response.Error) {
+	var section curriculumEntity.Section
+
+	if err := repository.db.Select("id, product_id").First(&section, sectionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, &response.Error{
+				Code: 404,
+				Err:  errors.New("section not found"),
+			}
+		}
+
+		return 0, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return section.ProductID, nil
+}
+
+// FindProductsByInstructorId implements InstructorRepository.
+func (repository *instructorRepository) FindProductsByInstructorId(instructorID int64) []productEntity.Product {
+	var products []productEntity.Product
+
+	repository.db.
+		Preload("ProductCategory").
+		Joins("JOIN product_instructors ON product_instructors.product_id = products.id").
+		Where("product_instructors.instructor_id = ?", instructorID).
+		Order("products.id DESC").
+		Find(&products)
+
+	return products
+}
+
+// FindTrashedByUserId implements InstructorRepository.
+func (repository *instructorRepository) FindTrashedByUserId(userID int64) *entity.Instructor {
+	var instructor entity.Instructor
+
+	err := repository.db.
+		Unscoped().
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		First(&instructor).
+		Error
+
+	if err != nil {
+		return nil
+	}
+
+	return &instructor
+}
+
+// IsAssigned implements InstructorRepository.
+func (repository *instructorRepository) IsAssigned(instructorID int64, productID int64) bool {
+	var count int64
+
+	repository.db.Model(&entity.ProductInstructor{}).
+		Where("instructor_id = ? AND product_id = ?", instructorID, productID).
+		Count(&count)
+
+	return count > 0
+}
+
+// ReplaceProductInstructors implements InstructorRepository.
+func (repository *instructorRepository) ReplaceProductInstructors(productID int64, instructorIDs []int64, createdBy *int64) *response.Error {
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", productID).Delete(&entity.ProductInstructor{}).Error; err != nil {
+			return err
+		}
+
+		if len(instructorIDs) == 0 {
+			return nil
+		}
+
+		productInstructors := make([]entity.ProductInstructor, 0, len(instructorIDs))
+
+		for _, instructorID := range instructorIDs {
+			productInstructors = append(productInstructors, entity.ProductInstructor{
+				ProductID:    productID,
+				InstructorID: instructorID,
+				CreatedByID:  createdBy,
+			})
+		}
+
+		return tx.Create(&productInstructors).Error
+	})
+
+	if err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// Restore implements InstructorRepository.
+func (repository *instructorRepository) Restore(instructor entity.Instructor) (*entity.Instructor, *response.Error) {
+	instructor.DeletedAt = gorm.DeletedAt{}
+
+	return repository.Update(instructor)
+}
+
+// Update implements InstructorRepository.
+func (repository *instructorRepository) Update(instructor entity.Instructor) (*entity.Instructor, *response.Error) {
+	instructor.User = nil
+	instructor.Products = nil
+
+	if err := repository.db.Unscoped().Save(&instructor).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &instructor, nil
+}
+
+func NewInstructorRepository(db *gorm.DB) InstructorRepository {
+	return &instructorRepository{db}
+}
@@ -0,0 +1,453 @@
+package instructor
+
+import (
+	"errors"
+
+	curriculumDto "e-course-management/internal/curriculum/dto"
+	curriculumEntity "e-course-management/internal/curriculum/entity"
+	curriculumUseCase "e-course-management/internal/curriculum/usecase"
+	dto "e-course-management/internal/instructor/dto"
+	entity "e-course-management/internal/instructor/entity"
+	repository "e-course-management/internal/instructor/repository"
+	productDto "e-course-management/internal/product/dto"
+	productEntity "e-course-management/internal/product/entity"
+	productUseCase "e-course-management/internal/product/usecase"
+	userUseCase "e-course-management/internal/user/usecase"
+	"e-course-management/pkg/response"
+)
+
+// InstructorUseCase manages instructor profiles and their course assignment,
+// and lets instructors edit the courses assigned to them. The created_by and
+// updated_by columns reference admins, so they are left empty on edits made
+// by instructors.
+type InstructorUseCase interface {
+	FindAll(offset int, limit int) []entity.Instructor
+	FindOneById(id int) (*entity.Instructor, *response.Error)
+	Create(request dto.InstructorRequestBody) (*entity.Instructor, *response.Error)
+	Update(id int, request dto.InstructorUpdateRequestBody) (*entity.Instructor, *response.Error)
+	Delete(id int, deletedBy int64) *response.Error
+	FindAllByProduct(productID int) ([]dto.InstructorProfileResponse, *response.Error)
+	AssignProduct(productID int, request dto.ProductInstructorRequestBody) ([]dto.InstructorProfileResponse, *response.Error)
+	FindMe(userID int64) (*entity.Instructor, *response.Error)
+	FindMyProducts(userID int64) ([]productEntity.Product, *response.Error)
+	UpdateProduct(userID int64, productID int, request productDto.ProductRequestBody) (*productEntity.Product, *response.Error)
+	CreateSection(userID int64, productID int, request curriculumDto.SectionRequestBody) (*curriculumEntity.Section, *response.Error)
+	UpdateSection(userID int64, id int, request curriculumDto.SectionRequestBody) (*curriculumEntity.Section, *response.Error)
+	DeleteSection(userID int64, id int) *response.Error
+	ReorderSections(userID int64, productID int, request curriculumDto.ReorderRequestBody) ([]curriculumEntity.Section, *response.Error)
+	CreateLesson(userID int64, sectionID int, request curriculumDto.LessonRequestBody) (*curriculumEntity.Lesson, *response.Error)
+	UpdateLesson(userID int64, id int, request curriculumDto.LessonRequestBody) (*curriculumEntity.Lesson, *response.Error)
+	DeleteLesson(userID int64, id int) *response.Error
+	ReorderLessons(userID int64, sectionID int, request curriculumDto.ReorderRequestBody) ([]curriculumEntity.Lesson, *response.Error)
+	Dashboard(userID int64) (*dto.InstructorDashboardResponse, *response.Error)
+	FindEnrollments(userID int64, filter dto.InstructorEnrollmentFilterRequest) (*dto.InstructorEnrollmentListResponse, *response.Error)
+}
+
+type instructorUseCase struct {
+	repository        repository.InstructorRepository
+	userUseCase       userUseCase.UserUseCase
+	productUseCase    productUseCase.ProductUseCase
+	curriculumUseCase curriculumUseCase.CurriculumUseCase
+}
+
+// AssignProduct implements InstructorUseCase.
+func (usecase *instructorUseCase) AssignProduct(productID int, request dto.ProductInstructorRequestBody) ([]dto.InstructorProfileResponse, *response.Error) {
+	product, err := usecase.productUseCase.FindOneById(productID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(request.InstructorIDs))
+	seen := make(map[int64]bool, len(request.InstructorIDs))
+
+	for _, id := range request.InstructorIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) > 0 && usecase.repository.CountByIds(ids) != int64(len(ids)) {
+		return nil, &response.Error{
+			Code: 400,
+			Err:  errors.New("some instructors do not exist"),
+		}
+	}
+
+	if err := usecase.repository.ReplaceProductInstructors(product.ID, ids, request.CreatedBy); err != nil {
+		return nil, err
+	}
+
+	return profiles(usecase.repository.FindAllByProductId(product.ID)), nil
+}
+
+// Create implements InstructorUseCase.
+// A user whose instructor profile was deleted gets it back.
+func (usecase *instructorUseCase) Create(request dto.InstructorRequestBody) (*entity.Instructor, *response.Error) {
+	user, err := usecase.userUseCase.FindOneById(int(request.UserID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := usecase.repository.FindOneByUserId(user.ID); err == nil {
+		return nil, &response.Error{
+			Code: 409,
+			Err:  errors.New("user is already an instructor"),
+		}
+	} else if err.Code != 404 {
+		return nil, err
+	}
+
+	if trashed := usecase.repository.FindTrashedByUserId(user.ID); trashed != nil {
+		trashed.Headline = request.Headline
+		trashed.Bio = request.Bio
+		trashed.UpdatedByID = request.CreatedBy
+
+		return usecase.repository.Restore(*trashed)
+	}
+
+	instructor := entity.Instructor{
+		UserID:      user.ID,
+		Headline:    request.Headline,
+		Bio:         request.Bio,
+		CreatedByID: request.CreatedBy,
+	}
+
+	return usecase.repository.Create(instructor)
+}
+
+// CreateLesson implements InstructorUseCase.
+func (usecase *instructorUseCase) CreateLesson(userID int64, sectionID int, request curriculumDto.LessonRequestBody) (*curriculumEntity.Lesson, *response.Error) {
+	if err := usecase.authorizeSection(userID, sectionID); err != nil {
+		return nil, err
+	}
+
+	request.CreatedBy = nil
+
+	return usecase.curriculumUseCase.CreateLesson(sectionID, request)
+}
+
+// CreateSection implements InstructorUseCase.
+func (usecase *instructorUseCase) CreateSection(userID int64, productID int, request curriculumDto.SectionRequestBody) (*curriculumEntity.Section, *response.Error) {
+	if _, err := usecase.authorize(userID, int64(productID)); err != nil {
+		return nil, err
+	}
+
+	request.CreatedBy = nil
+
+	return usecase.curriculumUseCase.CreateSection(productID, request)
+}
+
+// Dashboard implements InstructorUseCase.
+func (usecase *instructorUseCase) Dashboard(userID int64) (*dto.InstructorDashboardResponse, *response.Error) {
+	instructor, err := usecase.FindMe(userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.InstructorDashboardResponse{
+		Instructor: profile(*instructor),
+		Courses:    usecase.repository.FindCourseStats(instructor.ID),
+	}
+
+	for _, course := range result.Courses {
+		result.TotalEnrollments += course.Enrollments
+		result.TotalCompletions += course.Completions
+		result.TotalSales += course.Sales
+		result.TotalRevenue += course.Revenue
+	}
+
+	result.TotalCourses = len(result.Courses)
+
+	if result.Courses == nil {
+		result.Courses = []dto.InstructorCourseStats{}
+	}
+
+	return result, nil
+}
+
+// Delete implements InstructorUseCase.
+func (usecase *instructorUseCase) Delete(id int, deletedBy int64) *response.Error {
+	instructor, err := usecase.repository.FindOneById(id)
+
+	if err != nil {
+		return err
+	}
+
+	instructor.UpdatedByID = &deletedBy
+
+	return usecase.repository.Delete(*instructor)
+}
+
+// DeleteLesson implements InstructorUseCase.
+func (usecase *instructorUseCase) DeleteLesson(userID int64, id int) *response.Error {
+	if err := usecase.authorizeLesson(userID, id); err != nil {
+		return err
+	}
+
+	return usecase.curriculumUseCase.DeleteLesson(id, nil)
+}
+
+// DeleteSection implements InstructorUseCase.
+func (usecase *instructorUseCase) DeleteSection(userID int64, id int) *response.Error {
+	if err := usecase.authorizeSection(userID, id); err != nil {
+		return err
+	}
+
+	return usecase.curriculumUseCase.DeleteSection(id, nil)
+}
+
+// FindAll implements InstructorUseCase.
+func (usecase *instructorUseCase) FindAll(offset int, limit int) []entity.Instructor {
+	return usecase.repository.FindAll(offset, limit)
+}
+
+// FindAllByProduct implements InstructorUseCase.
+func (usecase *instructorUseCase) FindAllByProduct(productID int) ([]dto.InstructorProfileResponse, *response.Error) {
+	product, err := usecase.productUseCase.FindOneById(productID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return profiles(usecase.repository.FindAllByProductId(product.ID)), nil
+}
+
+// FindEnrollments implements InstructorUseCase.
+func (usecase *instructorUseCase) FindEnrollments(userID int64, filter dto.InstructorEnrollmentFilterRequest) (*dto.InstructorEnrollmentListResponse, *response.Error) {
+	instructor, err := usecase.FindMe(userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	classRooms, total := usecase.repository.FindEnrollments(instructor.ID, filter)
+
+	result := &dto.InstructorEnrollmentListResponse{
+		Enrollments: make([]dto.InstructorEnrollment, 0, len(classRooms)),
+		Total:       total,
+		Offset:      filter.Offset,
+		Limit:       filter.Limit,
+	}
+
+	for _, classRoom := range classRooms {
+		enrollment := dto.InstructorEnrollment{
+			ClassRoomID: classRoom.ID,
+			Progress:    classRoom.Progress,
+			CompletedAt: classRoom.CompletedAt,
+			EnrolledAt:  classRoom.CreatedAt,
+		}
+
+		if classRoom.Product != nil {
+			enrollment.ProductID = classRoom.Product.ID
+			enrollment.ProductTitle = classRoom.Product.Title
+		}
+
+		if classRoom.User != nil {
+			enrollment.UserID = classRoom.User.ID
+			enrollment.UserName = classRoom.User.Name
+		}
+
+		result.Enrollments = append(result.Enrollments, enrollment)
+	}
+
+	return result, nil
+}
+
+// FindMe implements InstructorUseCase.
+func (usecase *instructorUseCase) FindMe(userID int64) (*entity.Instructor, *response.Error) {
+	instructor, err := usecase.repository.FindOneByUserId(userID)
+
+	if err != nil {
+		if err.Code == 404 {
+			return nil, &response.Error{
+				Code: 403,
+				Err:  errors.New("only instructors can access this resource"),
+			}
+		}
+
+		return nil, err
+	}
+
+	return instructor, nil
+}
+
+// FindMyProducts implements InstructorUseCase.
+func (usecase *instructorUseCase) FindMyProducts(userID int64) ([]productEntity.Product, *response.Error) {
+	instructor, err := usecase.FindMe(userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	products := usecase.repository.FindProductsByInstructorId(instructor.ID)
+
+	if products == nil {
+		products = []productEntity.Product{}
+	}
+
+	return products, nil
+}
+
+// FindOneById implements InstructorUseCase.
+func (usecase *instructorUseCase) FindOneById(id int) (*entity.Instructor, *response.Error) {
+	return usecase.repository.FindOneById(id)
+}
+
+// ReorderLessons implements InstructorUseCase.
+func (usecase *instructorUseCase) ReorderLessons(userID int64, sectionID int, request curriculumDto.ReorderRequestBody) ([]curriculumEntity.Lesson, *response.Error) {
+	if err := usecase.authorizeSection(userID, sectionID); err != nil {
+		return nil, err
+	}
+
+	request.UpdatedBy = nil
+
+	return usecase.curriculumUseCase.ReorderLessons(sectionID, request)
+}
+
+// ReorderSections implements InstructorUseCase.
+func (usecase *instructorUseCase) ReorderSections(userID int64, productID int, request curriculumDto.ReorderRequestBody) ([]curriculumEntity.Section, *response.Error) {
+	if _, err := usecase.authorize(userID, int64(productID)); err != nil {
+		return nil, err
+	}
+
+	request.UpdatedBy = nil
+
+	return usecase.curriculumUseCase.ReorderSections(productID, request)
+}
+
+// Update implements InstructorUseCase.
+func (usecase *instructorUseCase) Update(id int, request dto.InstructorUpdateRequestBody) (*entity.Instructor, *response.Error) {
+	instructor, err := usecase.repository.FindOneById(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	instructor.Headline = request.Headline
+	instructor.Bio = request.Bio
+	instructor.UpdatedByID = request.UpdatedBy
+
+	return usecase.repository.Update(*instructor)
+}
+
+// UpdateLesson implements InstructorUseCase.
+// Lessons can only be moved to sections of the same course.
+func (usecase *instructorUseCase) UpdateLesson(userID int64, id int, request curriculumDto.LessonRequestBody) (*curriculumEntity.Lesson, *response.Error) {
+	if err := usecase.authorizeLesson(userID, id); err != nil {
+		return nil, err
+	}
+
+	request.UpdatedBy = nil
+
+	return usecase.curriculumUseCase.UpdateLesson(id, request)
+}
+
+// UpdateProduct implements InstructorUseCase.
+// The price and the highlight of a course stay under admin control.
+func (usecase *instructorUseCase) UpdateProduct(userID int64, productID int, request productDto.ProductRequestBody) (*productEntity.Product, *response.Error) {
+	if _, err := usecase.authorize(userID, int64(productID)); err != nil {
+		return nil, err
+	}
+
+	product, err := usecase.productUseCase.FindOneById(productID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	request.Price = product.Price
+	request.IsHighlighted = product.IsHighlighted
+	request.UpdatedBy = nil
+
+	return usecase.productUseCase.Update(productID, request)
+}
+
+// UpdateSection implements InstructorUseCase.
+func (usecase *instructorUseCase) UpdateSection(userID int64, id int, request curriculumDto.SectionRequestBody) (*curriculumEntity.Section, *response.Error) {
+	if err := usecase.authorizeSection(userID, id); err != nil {
+		return nil, err
+	}
+
+	request.UpdatedBy = nil
+
+	return usecase.curriculumUseCase.UpdateSection(id, request)
+}
+
+// authorize checks that the user is an instructor of the product
+func (usecase *instructorUseCase) authorize(userID int64, productID int64) (*entity.Instructor, *response.Error) {
+	instructor, err := usecase.FindMe(userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !usecase.repository.IsAssigned(instructor.ID, productID) {
+		return nil, &response.Error{
+			Code: 403,
+			Err:  errors.New("you are not an instructor of this course"),
+		}
+	}
+
+	return instructor, nil
+}
+
+func (usecase *instructorUseCase) authorizeLesson(userID int64, lessonID int) *response.Error {
+	productID, err := usecase.repository.FindProductIdByLessonId(lessonID)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = usecase.authorize(userID, productID)
+
+	return err
+}
+
+func (usecase *instructorUseCase) authorizeSection(userID int64, sectionID int) *response.Error {
+	productID, err := usecase.repository.FindProductIdBySectionId(sectionID)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = usecase.authorize(userID, productID)
+
+	return err
+}
+
+func profile(instructor entity.Instructor) dto.InstructorProfileResponse {
+	result := dto.InstructorProfileResponse{
+		ID:       instructor.ID,
+		Headline: instructor.Headline,
+		Bio:      instructor.Bio,
+	}
+
+	if instructor.User != nil {
+		result.Name = instructor.User.Name
+	}
+
+	return result
+}
+
+func profiles(instructors []entity.Instructor) []dto.InstructorProfileResponse {
+	result := make([]dto.InstructorProfileResponse, 0, len(instructors))
+
+	for _, instructor := range instructors {
+		result = append(result, profile(instructor))
+	}
+
+	return result
+}
+
+func NewInstructorUseCase(
+	repository repository.InstructorRepository,
+	userUseCase userUseCase.UserUseCase,
+	productUseCase productUseCase.ProductUseCase,
+	curriculumUseCase curriculumUseCase.CurriculumUseCase,
+) InstructorUseCase {
+	return &instructorUseCase{repository, userUseCase, productUseCase, curriculumUseCase}
+}
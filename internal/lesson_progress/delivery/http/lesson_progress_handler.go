@@ -0,0 +1,140 @@
+package lesson_progress
+
+import (
+	"net/http"
+	"strconv"
+
+	dto "e-course-management/internal/lesson_progress/dto"
+	usecase "e-course-management/internal/lesson_progress/usecase"
+	"e-course-management/internal/middleware"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LessonProgressHandler struct {
+	usecase usecase.LessonProgressUseCase
+}
+
+func NewLessonProgressHandler(usecase usecase.LessonProgressUseCase) *LessonProgressHandler {
+	return &LessonProgressHandler{usecase}
+}
+
+func (handler *LessonProgressHandler) Route(r *gin.RouterGroup) {
+	lessonProgressRouter := r.Group("/api/v1")
+
+	lessonProgressRouter.Use(middleware.AuthJwt, middleware.AuthUser)
+	{
+		lessonProgressRouter.POST("/lessons/:id/progress", handler.Track)
+		lessonProgressRouter.GET("/products/:id/progress", handler.CourseProgress)
+		lessonProgressRouter.GET("/products/:id/progress/continue", handler.Continue)
+		lessonProgressRouter.GET("/progress/continue", handler.ContinueLatest)
+	}
+}
+
+func (handler *LessonProgressHandler) Track(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input dto.LessonProgressRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.Track(id, user.ID, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *LessonProgressHandler) CourseProgress(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.CourseProgress(id, user.ID)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *LessonProgressHandler) Continue(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.Continue(id, user.ID)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *LessonProgressHandler) ContinueLatest(ctx *gin.Context) {
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.ContinueLatest(user.ID)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
@@ -0,0 +1,14 @@
+package lesson_progress
+
+type LessonProgressRequestBody struct {
+	// Position is the video position in seconds
+	Position  *int `json:"position" binding:"omitempty,min=0"`
+	Completed bool `json:"completed"`
+}
+
+type CourseCompletedEmail struct {
+	NAME               string
+	COURSE             string
+	COMPLETED_AT       string
+	CERTIFICATE_SERIAL string
+}
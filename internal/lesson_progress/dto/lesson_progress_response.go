@@ -0,0 +1,35 @@
+package lesson_progress
+
+import (
+	"time"
+
+	entity "e-course-management/internal/lesson_progress/entity"
+)
+
+type CourseProgressResponse struct {
+	ProductID        int64                   `json:"product_id"`
+	ClassRoomID      int64                   `json:"class_room_id"`
+	Progress         int                     `json:"progress"`
+	CompletedLessons int                     `json:"completed_lessons"`
+	RequiredLessons  int                     `json:"required_lessons"`
+	CompletedAt      *time.Time              `json:"completed_at"`
+	Lessons          []entity.LessonProgress `json:"lessons"`
+}
+
+type LessonProgressResponse struct {
+	Lesson entity.LessonProgress  `json:"lesson"`
+	Course CourseProgressResponse `json:"course"`
+}
+
+// ContinueResponse points at the lesson to open next
+type ContinueResponse struct {
+	ProductID         int64  `json:"product_id"`
+	ProductTitle      string `json:"product_title"`
+	SectionID         int64  `json:"section_id"`
+	LessonID          int64  `json:"lesson_id"`
+	LessonTitle       string `json:"lesson_title"`
+	LessonType        string `json:"lesson_type"`
+	LastPosition      int    `json:"last_position"`
+	Progress          int    `json:"progress"`
+	IsCourseCompleted bool   `json:"is_course_completed"`
+}
@@ -0,0 +1,22 @@
+package lesson_progress
+
+import (
+	curriculum "e-course-management/internal/curriculum/entity"
+	"time"
+)
+
+// LessonProgress is kept per class room, so a user who is enrolled again
+// starts the course from scratch. LastPosition is the video position in
+// seconds.
+type LessonProgress struct {
+	ID           int64              `json:"id"`
+	UserID       int64              `json:"user_id"`
+	ClassRoomID  int64              `json:"class_room_id"`
+	LessonID     int64              `json:"lesson_id"`
+	Lesson       *curriculum.Lesson `json:"-" gorm:"foreignKey:LessonID;references:ID"`
+	LastPosition int                `json:"last_position"`
+	StartedAt    *time.Time         `json:"started_at"`
+	CompletedAt  *time.Time         `json:"completed_at"`
+	CreatedAt    *time.Time         `json:"created_at"`
+	UpdatedAt    *time.Time         `json:"updated_at"`
+}
@@ -0,0 +1,39 @@
+//go:build wireinject
+// +build wireinject
+
+package lesson_progress
+
+import (
+	certificateRepository "e-course-management/internal/certificate/repository"
+	certificateUseCase "e-course-management/internal/certificate/usecase"
+	emailOutboxRepository "e-course-management/internal/email_outbox/repository"
+	emailSuppressionRepository "e-course-management/internal/email_suppression/repository"
+	handler "e-course-management/internal/lesson_progress/delivery/http"
+	repository "e-course-management/internal/lesson_progress/repository"
+	usecase "e-course-management/internal/lesson_progress/usecase"
+	mediaRepository "e-course-management/internal/media/repository"
+	mediaUseCase "e-course-management/internal/media/usecase"
+	mail "e-course-management/pkg/mail/sendgrid"
+	"e-course-management/pkg/storage"
+
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+func InitializedService(db *gorm.DB) *handler.LessonProgressHandler {
+	wire.Build(
+		handler.NewLessonProgressHandler,
+		usecase.NewLessonProgressUseCase,
+		repository.NewLessonProgressRepository,
+		certificateUseCase.NewCertificateUseCase,
+		certificateRepository.NewCertificateRepository,
+		mediaUseCase.NewMediaUseCase,
+		mediaRepository.NewMediaRepository,
+		storage.NewStorage,
+		mail.NewMailUseCase,
+		emailOutboxRepository.NewEmailOutboxRepository,
+		emailSuppressionRepository.NewEmailSuppressionRepository,
+	)
+
+	return &handler.LessonProgressHandler{}
+}
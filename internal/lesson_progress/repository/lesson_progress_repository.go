@@ -0,0 +1,222 @@
+package lesson_progress
+
+import (
+	"errors"
+	"time"
+
+	classRoomEntity "e-course-management/internal/class_room/entity"
+	curriculumEntity "e-course-management/internal/curriculum/entity"
+	entity "e-course-management/internal/lesson_progress/entity"
+	"e-course-management/pkg/response"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LessonProgressRepository interface {
+	FindClassRoom(userID int64, productID int64) (*classRoomEntity.ClassRoom, *response.Error)
+	FindLessonById(id int) (*curriculumEntity.Lesson, *response.Error)
+	FindLessonsByProductId(productID int64) []curriculumEntity.Lesson
+	FindAllByClassRoomId(classRoomID int64) []entity.LessonProgress
+	FindOne(classRoomID int64, lessonID int64) *entity.LessonProgress
+	FindLatestByUserId(userID int64) (*entity.LessonProgress, *response.Error)
+	Upsert(progress entity.LessonProgress) (*entity.LessonProgress, *response.Error)
+	UpdateClassRoomProgress(classRoomID int64, progress int) *response.Error
+	MarkClassRoomCompleted(classRoomID int64, completedAt time.Time) (bool, *response.Error)
+	WithTx(tx *gorm.DB) LessonProgressRepository
+}
+
+type lessonProgressRepository struct {
+	db *gorm.DB
+}
+
+// FindAllByClassRoomId implements LessonProgressRepository.
+func (repository *lessonProgressRepository) FindAllByClassRoomId(classRoomID int64) []entity.LessonProgress {
+	var progresses []entity.LessonProgress
+
+	repository.db.Where("class_room_id = ?", classRoomID).Order("updated_at DESC, id DESC").Find(&progresses)
+
+	return progresses
+}
+
+// FindClassRoom implements LessonProgressRepository.
+func (repository *lessonProgressRepository) FindClassRoom(userID int64, productID int64) (*classRoomEntity.ClassRoom, *response.Error) {
+	var classRoom classRoomEntity.ClassRoom
+
+	err := repository.db.
+		Preload("User").
+		Preload("Product").
+		Where("user_id = ? AND product_id = ?", userID, productID).
+		First(&classRoom).
+		Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 403,
+				Err:  errors.New("you are not enrolled in this course"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &classRoom, nil
+}
+
+// FindLatestByUserId implements LessonProgressRepository.
+func (repository *lessonProgressRepository) FindLatestByUserId(userID int64) (*entity.LessonProgress, *response.Error) {
+	var progress entity.LessonProgress
+
+	err := repository.db.
+		Preload("Lesson.Section").
+		Where("user_id = ?", userID).
+		Order("updated_at DESC, id DESC").
+		First(&progress).
+		Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("no course in progress"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &progress, nil
+}
+
+// FindOne implements LessonProgressRepository.
+func (repository *lessonProgressRepository) FindOne(classRoomID int64, lessonID int64) *entity.LessonProgress {
+	var progress entity.LessonProgress
+
+	if err := repository.db.Where("class_room_id = ? AND lesson_id = ?", classRoomID, lessonID).First(&progress).Error; err != nil {
+		return nil
+	}
+
+	return &progress
+}
+
+// FindLessonById implements LessonProgressRepository.
+func (repository *lessonProgressRepository) FindLessonById(id int) (*curriculumEntity.Lesson, *response.Error) {
+	var lesson curriculumEntity.Lesson
+
+	if err := repository.db.Preload("Section").First(&lesson, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("lesson not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &lesson, nil
+}
+
+// FindLessonsByProductId implements LessonProgressRepository.
+// Lessons are returned in curriculum order, skipping deleted sections.
+func (repository *lessonProgressRepository) FindLessonsByProductId(productID int64) []curriculumEntity.Lesson {
+	var lessons []curriculumEntity.Lesson
+
+	repository.db.
+		Joins("Section").
+		Where("Section.product_id = ? AND Section.deleted_at IS NULL", productID).
+		Order("Section.position, Section.id, lessons.position, lessons.id").
+		Find(&lessons)
+
+	return lessons
+}
+
+// MarkClassRoomCompleted implements LessonProgressRepository.
+// Only the first caller gets true, so the completion event fires once.
+func (repository *lessonProgressRepository) MarkClassRoomCompleted(classRoomID int64, completedAt time.Time) (bool, *response.Error) {
+	result := repository.db.Model(&classRoomEntity.ClassRoom{}).
+		Where("id = ? AND completed_at IS NULL", classRoomID).
+		Update("completed_at", completedAt)
+
+	if result.Error != nil {
+		return false, &response.Error{
+			Code: 500,
+			Err:  result.Error,
+		}
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// UpdateClassRoomProgress implements LessonProgressRepository.
+func (repository *lessonProgressRepository) UpdateClassRoomProgress(classRoomID int64, progress int) *response.Error {
+	err := repository.db.Model(&classRoomEntity.ClassRoom{}).
+		Where("id = ?", classRoomID).
+		Update("progress", progress).
+		Error
+
+	if err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// Upsert implements LessonProgressRepository.
+// Concurrent requests for the same lesson collapse onto one row; started_at
+// and completed_at keep their first value.
+func (repository *lessonProgressRepository) Upsert(progress entity.LessonProgress) (*entity.LessonProgress, *response.Error) {
+	err := repository.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "class_room_id"}, {Name: "lesson_id"}},
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: "last_position"}, Value: gorm.Expr("VALUES(last_position)")},
+			{Column: clause.Column{Name: "completed_at"}, Value: gorm.Expr("COALESCE(completed_at, VALUES(completed_at))")},
+			{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("VALUES(updated_at)")},
+		},
+	}).Omit("Lesson").Create(&progress).Error
+
+	if err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	var saved entity.LessonProgress
+
+	err = repository.db.
+		Where("class_room_id = ? AND lesson_id = ?", progress.ClassRoomID, progress.LessonID).
+		First(&saved).
+		Error
+
+	if err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &saved, nil
+}
+
+// WithTx implements LessonProgressRepository.
+func (repository *lessonProgressRepository) WithTx(tx *gorm.DB) LessonProgressRepository {
+	return &lessonProgressRepository{tx}
+}
+
+func NewLessonProgressRepository(db *gorm.DB) LessonProgressRepository {
+	return &lessonProgressRepository{db}
+}
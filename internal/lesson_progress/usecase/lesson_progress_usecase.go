@@ -0,0 +1,386 @@
+package lesson_progress
+
+import (
+	"errors"
+	"time"
+
+	certificateUseCase "e-course-management/internal/certificate/usecase"
+	classRoomEntity "e-course-management/internal/class_room/entity"
+	curriculumEntity "e-course-management/internal/curriculum/entity"
+	dto "e-course-management/internal/lesson_progress/dto"
+	entity "e-course-management/internal/lesson_progress/entity"
+	repository "e-course-management/internal/lesson_progress/repository"
+	mail "e-course-management/pkg/mail/sendgrid"
+	"e-course-management/pkg/response"
+
+	"gorm.io/gorm"
+)
+
+// a video lesson counts as completed once this share of it has been watched
+const (
+	watchedNumerator   = 9
+	watchedDenominator = 10
+)
+
+type LessonProgressUseCase interface {
+	Track(lessonID int, userID int64, request dto.LessonProgressRequestBody) (*dto.LessonProgressResponse, *response.Error)
+	Complete(lessonID int64, userID int64) (*dto.LessonProgressResponse, *response.Error)
+	CourseProgress(productID int, userID int64) (*dto.CourseProgressResponse, *response.Error)
+	Continue(productID int, userID int64) (*dto.ContinueResponse, *response.Error)
+	ContinueLatest(userID int64) (*dto.ContinueResponse, *response.Error)
+	WithTx(tx *gorm.DB) LessonProgressUseCase
+}
+
+type lessonProgressUseCase struct {
+	db                 *gorm.DB
+	repository         repository.LessonProgressRepository
+	certificateUseCase certificateUseCase.CertificateUseCase
+	mail               mail.Mail
+}
+
+// Continue implements LessonProgressUseCase.
+// It resumes the most recently opened lesson that is not completed yet,
+// otherwise the first unfinished lesson of the curriculum.
+func (usecase *lessonProgressUseCase) Continue(productID int, userID int64) (*dto.ContinueResponse, *response.Error) {
+	classRoom, err := usecase.repository.FindClassRoom(userID, int64(productID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	lessons := usecase.repository.FindLessonsByProductId(*classRoom.ProductID)
+
+	if len(lessons) == 0 {
+		return nil, &response.Error{
+			Code: 404,
+			Err:  errors.New("course has no lessons yet"),
+		}
+	}
+
+	progresses := usecase.repository.FindAllByClassRoomId(classRoom.ID)
+	byLesson := make(map[int64]entity.LessonProgress, len(progresses))
+
+	for _, progress := range progresses {
+		byLesson[progress.LessonID] = progress
+	}
+
+	lessonsByID := make(map[int64]curriculumEntity.Lesson, len(lessons))
+
+	for _, lesson := range lessons {
+		lessonsByID[lesson.ID] = lesson
+	}
+
+	var next *curriculumEntity.Lesson
+	lastPosition := 0
+
+	// progresses are sorted by most recent activity first
+	for _, progress := range progresses {
+		lesson, ok := lessonsByID[progress.LessonID]
+
+		if ok && progress.CompletedAt == nil {
+			next = &lesson
+			lastPosition = progress.LastPosition
+			break
+		}
+	}
+
+	if next == nil {
+		for i := range lessons {
+			if progress, ok := byLesson[lessons[i].ID]; !ok || progress.CompletedAt == nil {
+				next = &lessons[i]
+				break
+			}
+		}
+	}
+
+	if next == nil {
+		next = &lessons[0]
+	}
+
+	result := &dto.ContinueResponse{
+		ProductID:         *classRoom.ProductID,
+		SectionID:         next.SectionID,
+		LessonID:          next.ID,
+		LessonTitle:       next.Title,
+		LessonType:        next.Type,
+		LastPosition:      lastPosition,
+		Progress:          classRoom.Progress,
+		IsCourseCompleted: classRoom.CompletedAt != nil,
+	}
+
+	if classRoom.Product != nil {
+		result.ProductTitle = classRoom.Product.Title
+	}
+
+	return result, nil
+}
+
+// ContinueLatest implements LessonProgressUseCase.
+func (usecase *lessonProgressUseCase) ContinueLatest(userID int64) (*dto.ContinueResponse, *response.Error) {
+	latest, err := usecase.repository.FindLatestByUserId(userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if latest.Lesson == nil || latest.Lesson.Section == nil {
+		return nil, &response.Error{
+			Code: 404,
+			Err:  errors.New("no course in progress"),
+		}
+	}
+
+	return usecase.Continue(int(latest.Lesson.Section.ProductID), userID)
+}
+
+// CourseProgress implements LessonProgressUseCase.
+func (usecase *lessonProgressUseCase) CourseProgress(productID int, userID int64) (*dto.CourseProgressResponse, *response.Error) {
+	classRoom, err := usecase.repository.FindClassRoom(userID, int64(productID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return courseProgress(
+		*classRoom,
+		usecase.repository.FindLessonsByProductId(*classRoom.ProductID),
+		usecase.repository.FindAllByClassRoomId(classRoom.ID),
+	), nil
+}
+
+// Complete implements LessonProgressUseCase.
+// It marks a lesson completed on behalf of another module, such as a passed
+// quiz, skipping the check that keeps users from completing quizzes directly.
+func (usecase *lessonProgressUseCase) Complete(lessonID int64, userID int64) (*dto.LessonProgressResponse, *response.Error) {
+	lesson, err := usecase.repository.FindLessonById(int(lessonID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return usecase.track(*lesson, userID, dto.LessonProgressRequestBody{Completed: true})
+}
+
+// Track implements LessonProgressUseCase.
+// The lesson progress, the class room percentage and the completion event
+// are written in one transaction.
+func (usecase *lessonProgressUseCase) Track(lessonID int, userID int64, request dto.LessonProgressRequestBody) (*dto.LessonProgressResponse, *response.Error) {
+	lesson, err := usecase.repository.FindLessonById(lessonID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Completed && lesson.Type == curriculumEntity.LessonTypeQuiz {
+		return nil, &response.Error{
+			Code: 400,
+			Err:  errors.New("quiz lessons are completed by passing the quiz"),
+		}
+	}
+
+	return usecase.track(*lesson, userID, request)
+}
+
+// WithTx implements LessonProgressUseCase.
+func (usecase *lessonProgressUseCase) WithTx(tx *gorm.DB) LessonProgressUseCase {
+	return &lessonProgressUseCase{
+		tx,
+		usecase.repository.WithTx(tx),
+		usecase.certificateUseCase.WithTx(tx),
+		usecase.mail.WithTx(tx),
+	}
+}
+
+func (usecase *lessonProgressUseCase) track(lesson curriculumEntity.Lesson, userID int64, request dto.LessonProgressRequestBody) (*dto.LessonProgressResponse, *response.Error) {
+	classRoom, err := usecase.repository.FindClassRoom(userID, lesson.Section.ProductID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result *dto.LessonProgressResponse
+
+	errTx := usecase.db.Transaction(func(tx *gorm.DB) error {
+		txRepository := usecase.repository.WithTx(tx)
+		now := time.Now()
+
+		progress := entity.LessonProgress{
+			UserID:      userID,
+			ClassRoomID: classRoom.ID,
+			LessonID:    lesson.ID,
+			StartedAt:   &now,
+		}
+
+		if existing := txRepository.FindOne(classRoom.ID, lesson.ID); existing != nil {
+			progress.LastPosition = existing.LastPosition
+		}
+
+		if request.Position != nil {
+			progress.LastPosition = *request.Position
+		}
+
+		if request.Completed || watched(lesson, progress.LastPosition) {
+			progress.CompletedAt = &now
+		}
+
+		saved, err := txRepository.Upsert(progress)
+
+		if err != nil {
+			return err
+		}
+
+		course, err := usecase.refresh(
+			txRepository,
+			usecase.certificateUseCase.WithTx(tx),
+			usecase.mail.WithTx(tx),
+			*classRoom,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		result = &dto.LessonProgressResponse{
+			Lesson: *saved,
+			Course: *course,
+		}
+
+		return nil
+	})
+
+	if errTx != nil {
+		return nil, response.FromError(errTx)
+	}
+
+	return result, nil
+}
+
+// refresh recalculates the class room percentage and, the first time every
+// required lesson is done, marks the course completed and fires the
+// completion event
+func (usecase *lessonProgressUseCase) refresh(
+	txRepository repository.LessonProgressRepository,
+	txCertificateUseCase certificateUseCase.CertificateUseCase,
+	txMail mail.Mail,
+	classRoom classRoomEntity.ClassRoom,
+) (*dto.CourseProgressResponse, *response.Error) {
+	course := courseProgress(
+		classRoom,
+		txRepository.FindLessonsByProductId(*classRoom.ProductID),
+		txRepository.FindAllByClassRoomId(classRoom.ID),
+	)
+
+	if course.Progress != classRoom.Progress {
+		if err := txRepository.UpdateClassRoomProgress(classRoom.ID, course.Progress); err != nil {
+			return nil, err
+		}
+	}
+
+	if course.RequiredLessons == 0 || course.CompletedLessons < course.RequiredLessons || classRoom.CompletedAt != nil {
+		return course, nil
+	}
+
+	now := time.Now()
+
+	claimed, err := txRepository.MarkClassRoomCompleted(classRoom.ID, now)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !claimed {
+		return course, nil
+	}
+
+	course.CompletedAt = &now
+	classRoom.CompletedAt = &now
+
+	if err := courseCompleted(txCertificateUseCase, txMail, classRoom); err != nil {
+		return nil, err
+	}
+
+	return course, nil
+}
+
+// courseCompleted is the completion event: it issues the certificate and
+// congratulates the user
+func courseCompleted(
+	txCertificateUseCase certificateUseCase.CertificateUseCase,
+	txMail mail.Mail,
+	classRoom classRoomEntity.ClassRoom,
+) *response.Error {
+	if classRoom.User == nil || classRoom.Product == nil {
+		return nil
+	}
+
+	certificate, err := txCertificateUseCase.Issue(classRoom)
+
+	if err != nil {
+		return err
+	}
+
+	return txMail.SendCourseCompleted(classRoom.User.Email, classRoom.User.Locale, dto.CourseCompletedEmail{
+		NAME:               classRoom.User.Name,
+		COURSE:             classRoom.Product.Title,
+		COMPLETED_AT:       classRoom.CompletedAt.Format("02 January 2006 15:04"),
+		CERTIFICATE_SERIAL: certificate.Serial,
+	})
+}
+
+func courseProgress(
+	classRoom classRoomEntity.ClassRoom,
+	lessons []curriculumEntity.Lesson,
+	progresses []entity.LessonProgress,
+) *dto.CourseProgressResponse {
+	completed := make(map[int64]bool, len(progresses))
+
+	for _, progress := range progresses {
+		if progress.CompletedAt != nil {
+			completed[progress.LessonID] = true
+		}
+	}
+
+	course := &dto.CourseProgressResponse{
+		ProductID:   *classRoom.ProductID,
+		ClassRoomID: classRoom.ID,
+		CompletedAt: classRoom.CompletedAt,
+		Lessons:     progresses,
+	}
+
+	for _, lesson := range lessons {
+		if lesson.IsOptional {
+			continue
+		}
+
+		course.RequiredLessons++
+
+		if completed[lesson.ID] {
+			course.CompletedLessons++
+		}
+	}
+
+	if course.RequiredLessons > 0 {
+		course.Progress = course.CompletedLessons * 100 / course.RequiredLessons
+	}
+
+	if course.Lessons == nil {
+		course.Lessons = []entity.LessonProgress{}
+	}
+
+	return course
+}
+
+func watched(lesson curriculumEntity.Lesson, position int) bool {
+	return lesson.Type == curriculumEntity.LessonTypeVideo &&
+		lesson.Duration > 0 &&
+		position*watchedDenominator >= lesson.Duration*watchedNumerator
+}
+
+func NewLessonProgressUseCase(
+	db *gorm.DB,
+	repository repository.LessonProgressRepository,
+	certificateUseCase certificateUseCase.CertificateUseCase,
+	mail mail.Mail,
+) LessonProgressUseCase {
+	return &lessonProgressUseCase{db, repository, certificateUseCase, mail}
+}
@@ -0,0 +1,198 @@
+package media
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	dto "e-course-management/internal/media/dto"
+	usecase "e-course-management/internal/media/usecase"
+	"e-course-management/internal/middleware"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead leaves room for the form fields and boundaries around the
+// uploaded file when limiting the request body
+const multipartOverhead = 1 << 20
+
+type MediaHandler struct {
+	usecase usecase.MediaUseCase
+}
+
+func NewMediaHandler(usecase usecase.MediaUseCase) *MediaHandler {
+	return &MediaHandler{usecase}
+}
+
+func (handler *MediaHandler) Route(r *gin.RouterGroup) {
+	// Files of the local storage driver
+	r.GET("/media/*key", handler.ServePublic)
+
+	mediaRouter := r.Group("/api/v1")
+
+	mediaRouter.GET("/media/stream/*key", handler.Stream)
+	mediaRouter.GET("/products/:id/video", middleware.AuthJwt, handler.VideoURL)
+
+	mediaRouter.Use(middleware.AuthJwt, middleware.AuthAdmin)
+	{
+		mediaRouter.POST("/media/images", handler.UploadImage)
+		mediaRouter.POST("/media/videos", handler.UploadVideo)
+	}
+}
+
+func (handler *MediaHandler) UploadImage(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, usecase.MaxImageSize()+multipartOverhead)
+
+	var input dto.ImageUploadRequestBody
+
+	if err := ctx.ShouldBind(&input); err != nil {
+		bindError(ctx, err)
+		return
+	}
+
+	data, err := handler.usecase.UploadImage(input.File, input.Folder)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Response(
+		http.StatusCreated,
+		http.StatusText(http.StatusCreated),
+		data,
+	))
+}
+
+func (handler *MediaHandler) UploadVideo(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, usecase.MaxVideoSize()+multipartOverhead)
+
+	var input dto.VideoUploadRequestBody
+
+	if err := ctx.ShouldBind(&input); err != nil {
+		bindError(ctx, err)
+		return
+	}
+
+	data, err := handler.usecase.UploadVideo(input.File)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Response(
+		http.StatusCreated,
+		http.StatusText(http.StatusCreated),
+		data,
+	))
+}
+
+func (handler *MediaHandler) VideoURL(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.VideoURL(id, user.ID, user.IsAdmin)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *MediaHandler) ServePublic(ctx *gin.Context) {
+	file, err := handler.usecase.OpenPublic(strings.TrimPrefix(ctx.Param("key"), "/"))
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	defer file.Close()
+
+	ctx.Header("Cache-Control", "public, max-age=31536000, immutable")
+	serveFile(ctx, file.Name(), file)
+}
+
+// Stream serves a signed video URL with range support so players can seek
+func (handler *MediaHandler) Stream(ctx *gin.Context) {
+	file, err := handler.usecase.OpenSigned(
+		strings.TrimPrefix(ctx.Param("key"), "/"),
+		ctx.Query("expires"),
+		ctx.Query("signature"),
+	)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	defer file.Close()
+
+	ctx.Header("Cache-Control", "private, no-store")
+	serveFile(ctx, file.Name(), file)
+}
+
+func bindError(ctx *gin.Context, err error) {
+	code := http.StatusBadRequest
+
+	var maxBytesError *http.MaxBytesError
+
+	if errors.As(err, &maxBytesError) {
+		code = http.StatusRequestEntityTooLarge
+	}
+
+	ctx.JSON(code, response.Response(
+		code,
+		http.StatusText(code),
+		err.Error(),
+	))
+	ctx.Abort()
+}
+
+func serveFile(ctx *gin.Context, name string, file *os.File) {
+	info, err := file.Stat()
+
+	if err != nil {
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+
+	http.ServeContent(ctx.Writer, ctx.Request, name, info.ModTime(), file)
+}
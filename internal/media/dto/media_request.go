@@ -0,0 +1,12 @@
+package media
+
+import "mime/multipart"
+
+type ImageUploadRequestBody struct {
+	File   *multipart.FileHeader `form:"file" binding:"required"`
+	Folder string                `form:"folder" binding:"required,oneof=products product_categories"`
+}
+
+type VideoUploadRequestBody struct {
+	File *multipart.FileHeader `form:"file" binding:"required"`
+}
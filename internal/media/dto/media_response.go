@@ -0,0 +1,16 @@
+package media
+
+import "time"
+
+type MediaResponse struct {
+	Key          string  `json:"key"`
+	URL          string  `json:"url,omitempty"`
+	ThumbnailURL *string `json:"thumbnail_url"`
+	ContentType  string  `json:"content_type"`
+	Size         int64   `json:"size"`
+}
+
+type SignedURLResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,25 @@
+//go:build wireinject
+// +build wireinject
+
+package media
+
+import (
+	handler "e-course-management/internal/media/delivery/http"
+	repository "e-course-management/internal/media/repository"
+	usecase "e-course-management/internal/media/usecase"
+	"e-course-management/pkg/storage"
+
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+func InitializedService(db *gorm.DB) *handler.MediaHandler {
+	wire.Build(
+		handler.NewMediaHandler,
+		usecase.NewMediaUseCase,
+		repository.NewMediaRepository,
+		storage.NewStorage,
+	)
+
+	return &handler.MediaHandler{}
+}
@@ -0,0 +1,56 @@
+package media
+
+import (
+	"errors"
+
+	classRoomEntity "e-course-management/internal/class_room/entity"
+	productEntity "e-course-management/internal/product/entity"
+	"e-course-management/pkg/response"
+
+	"gorm.io/gorm"
+)
+
+type MediaRepository interface {
+	FindProductVideo(productID int) (*string, *response.Error)
+	IsEnrolled(userID int64, productID int) bool
+}
+
+type mediaRepository struct {
+	db *gorm.DB
+}
+
+// FindProductVideo implements MediaRepository.
+func (repository *mediaRepository) FindProductVideo(productID int) (*string, *response.Error) {
+	var product productEntity.Product
+
+	if err := repository.db.Select("id", "video").First(&product, productID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("product not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return product.Video, nil
+}
+
+// IsEnrolled implements MediaRepository.
+func (repository *mediaRepository) IsEnrolled(userID int64, productID int) bool {
+	var count int64
+
+	repository.db.Model(&classRoomEntity.ClassRoom{}).
+		Where("user_id = ? AND product_id = ?", userID, productID).
+		Count(&count)
+
+	return count > 0
+}
+
+func NewMediaRepository(db *gorm.DB) MediaRepository {
+	return &mediaRepository{db}
+}
@@ -0,0 +1,344 @@
+package media
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	dto "e-course-management/internal/media/dto"
+	repository "e-course-management/internal/media/repository"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/storage"
+	"e-course-management/pkg/thumbnail"
+	"e-course-management/pkg/utils"
+)
+
+const (
+	defaultMaxImageSizeMB = 5
+	defaultMaxVideoSizeMB = 1024
+	defaultSignedURLTTL   = time.Hour
+	thumbnailWidth        = 320
+	sniffLength           = 512
+)
+
+// allowed content types, as detected from the file content, and the
+// extension they are stored with
+var (
+	imageTypes = map[string]string{
+		"image/jpeg": ".jpg",
+		"image/png":  ".png",
+		"image/gif":  ".gif",
+		"image/webp": ".webp",
+	}
+	videoTypes = map[string]string{
+		"video/mp4":  ".mp4",
+		"video/webm": ".webm",
+	}
+)
+
+type MediaUseCase interface {
+	UploadImage(file *multipart.FileHeader, folder string) (*dto.MediaResponse, *response.Error)
+	UploadVideo(file *multipart.FileHeader) (*dto.MediaResponse, *response.Error)
+	VideoURL(productID int, userID int64, isAdmin bool) (*dto.SignedURLResponse, *response.Error)
+	SignedURL(key string) (*dto.SignedURLResponse, *response.Error)
+	OpenPublic(key string) (*os.File, *response.Error)
+	OpenSigned(key string, expires string, signature string) (*os.File, *response.Error)
+}
+
+type mediaUseCase struct {
+	repository repository.MediaRepository
+	storage    storage.Storage
+}
+
+// UploadImage implements MediaUseCase. The thumbnail is generated before
+// anything is stored so undecodable files are rejected up front.
+func (usecase *mediaUseCase) UploadImage(file *multipart.FileHeader, folder string) (*dto.MediaResponse, *response.Error) {
+	if file.Size > MaxImageSize() {
+		return nil, &response.Error{
+			Code: 413,
+			Err:  fmt.Errorf("image must not be larger than %d MB", MaxImageSize()>>20),
+		}
+	}
+
+	src, err := file.Open()
+
+	if err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	defer src.Close()
+
+	contentType, ext, errSniff := sniff(src, imageTypes)
+
+	if errSniff != nil {
+		return nil, &response.Error{
+			Code: 415,
+			Err:  errors.New("image must be a jpg, png, gif or webp file"),
+		}
+	}
+
+	name := "images/" + folder + "/" + utils.RandString(32)
+	key := name + ext
+
+	result := &dto.MediaResponse{
+		Key:         key,
+		URL:         usecase.storage.URL(key),
+		ContentType: contentType,
+		Size:        file.Size,
+	}
+
+	// image/webp has no decoder in the standard library, those are kept
+	// without a thumbnail
+	if contentType != "image/webp" {
+		thumb, err := thumbnail.Generate(src, thumbnailWidth)
+
+		if errors.Is(err, thumbnail.ErrTooManyPixels) {
+			return nil, &response.Error{
+				Code: 413,
+				Err:  fmt.Errorf("image must not have more than %d megapixels", thumbnail.MaxPixels/1_000_000),
+			}
+		}
+
+		if err != nil {
+			return nil, &response.Error{
+				Code: 400,
+				Err:  errors.New("image could not be decoded"),
+			}
+		}
+
+		thumbKey := name + "_thumb.jpg"
+
+		if err := usecase.storage.Put(thumbKey, thumb, int64(thumb.Len()), "image/jpeg"); err != nil {
+			return nil, &response.Error{
+				Code: 500,
+				Err:  err,
+			}
+		}
+
+		thumbURL := usecase.storage.URL(thumbKey)
+		result.ThumbnailURL = &thumbURL
+
+		if _, err := src.Seek(0, io.SeekStart); err != nil {
+			return nil, &response.Error{
+				Code: 500,
+				Err:  err,
+			}
+		}
+	}
+
+	if err := usecase.storage.Put(key, src, file.Size, contentType); err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return result, nil
+}
+
+// UploadVideo implements MediaUseCase. Videos are private, the returned key
+// is stored on the product and streamed through VideoURL.
+func (usecase *mediaUseCase) UploadVideo(file *multipart.FileHeader) (*dto.MediaResponse, *response.Error) {
+	if file.Size > MaxVideoSize() {
+		return nil, &response.Error{
+			Code: 413,
+			Err:  fmt.Errorf("video must not be larger than %d MB", MaxVideoSize()>>20),
+		}
+	}
+
+	src, err := file.Open()
+
+	if err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	defer src.Close()
+
+	contentType, ext, errSniff := sniff(src, videoTypes)
+
+	if errSniff != nil {
+		return nil, &response.Error{
+			Code: 415,
+			Err:  errors.New("video must be a mp4 or webm file"),
+		}
+	}
+
+	key := "videos/" + utils.RandString(32) + ext
+
+	if err := usecase.storage.Put(key, src, file.Size, contentType); err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &dto.MediaResponse{
+		Key:         key,
+		ContentType: contentType,
+		Size:        file.Size,
+	}, nil
+}
+
+// VideoURL implements MediaUseCase.
+func (usecase *mediaUseCase) VideoURL(productID int, userID int64, isAdmin bool) (*dto.SignedURLResponse, *response.Error) {
+	video, err := usecase.repository.FindProductVideo(productID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if video == nil || *video == "" {
+		return nil, &response.Error{
+			Code: 404,
+			Err:  errors.New("product has no video"),
+		}
+	}
+
+	if !isAdmin && !usecase.repository.IsEnrolled(userID, productID) {
+		return nil, &response.Error{
+			Code: 403,
+			Err:  errors.New("you are not enrolled in this product"),
+		}
+	}
+
+	return usecase.SignedURL(*video)
+}
+
+// SignedURL implements MediaUseCase. It grants temporary access to a private
+// object such as a video or certificate; callers check who may read it.
+func (usecase *mediaUseCase) SignedURL(key string) (*dto.SignedURLResponse, *response.Error) {
+	ttl := signedURLTTL()
+
+	url, err := usecase.storage.SignedURL(key, ttl)
+
+	if err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &dto.SignedURLResponse{
+		URL:       url,
+		ExpiresAt: time.Now().Add(ttl),
+	}, nil
+}
+
+// OpenPublic implements MediaUseCase. Only images are public, and only the
+// local driver serves files itself.
+func (usecase *mediaUseCase) OpenPublic(key string) (*os.File, *response.Error) {
+	local, ok := usecase.storage.(*storage.LocalStorage)
+
+	if !ok || !storage.IsPublic(key) {
+		return nil, notFound()
+	}
+
+	file, err := local.Open(key)
+
+	if err != nil {
+		return nil, notFound()
+	}
+
+	return file, nil
+}
+
+// OpenSigned implements MediaUseCase.
+func (usecase *mediaUseCase) OpenSigned(key string, expires string, signature string) (*os.File, *response.Error) {
+	local, ok := usecase.storage.(*storage.LocalStorage)
+
+	if !ok {
+		return nil, notFound()
+	}
+
+	if !local.Verify(key, expires, signature) {
+		return nil, &response.Error{
+			Code: 403,
+			Err:  errors.New("signature is invalid or has expired"),
+		}
+	}
+
+	file, err := local.Open(key)
+
+	if err != nil {
+		return nil, notFound()
+	}
+
+	return file, nil
+}
+
+// MaxImageSize is the image upload limit in bytes, MEDIA_MAX_IMAGE_SIZE_MB
+func MaxImageSize() int64 {
+	return sizeFromEnv("MEDIA_MAX_IMAGE_SIZE_MB", defaultMaxImageSizeMB)
+}
+
+// MaxVideoSize is the video upload limit in bytes, MEDIA_MAX_VIDEO_SIZE_MB
+func MaxVideoSize() int64 {
+	return sizeFromEnv("MEDIA_MAX_VIDEO_SIZE_MB", defaultMaxVideoSizeMB)
+}
+
+func sizeFromEnv(name string, defaultMB int64) int64 {
+	size, err := strconv.ParseInt(os.Getenv(name), 10, 64)
+
+	if err != nil || size <= 0 {
+		size = defaultMB
+	}
+
+	return size << 20
+}
+
+func signedURLTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("MEDIA_SIGNED_URL_TTL"))
+
+	if err != nil || ttl <= 0 {
+		return defaultSignedURLTTL
+	}
+
+	return ttl
+}
+
+// sniff detects the content type from the first bytes of the file, ignoring
+// the extension and the Content-Type sent by the client, then rewinds it
+func sniff(file multipart.File, allowed map[string]string) (string, string, error) {
+	head := make([]byte, sniffLength)
+
+	n, err := io.ReadFull(file, head)
+
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", "", err
+	}
+
+	contentType := http.DetectContentType(head[:n])
+	ext, ok := allowed[contentType]
+
+	if !ok {
+		return "", "", fmt.Errorf("content type %s is not allowed", contentType)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", "", err
+	}
+
+	return contentType, ext, nil
+}
+
+func notFound() *response.Error {
+	return &response.Error{
+		Code: 404,
+		Err:  errors.New("file not found"),
+	}
+}
+
+func NewMediaUseCase(repository repository.MediaRepository, storage storage.Storage) MediaUseCase {
+	return &mediaUseCase{repository, storage}
+}
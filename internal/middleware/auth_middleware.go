@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+
+	dto "e-course-management/internal/oauth/dto"
+	"e-course-management/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type header struct {
+	Authorization string `header:"authorization" binding:"required"`
+}
+
+// AuthJwt parses the bearer token and stores its claims under the "user" key
+func AuthJwt(ctx *gin.Context) {
+	var input header
+
+	if err := ctx.ShouldBindHeader(&input); err != nil {
+		unauthorized(ctx, err)
+		return
+	}
+
+	claims, err := parseToken(input.Authorization)
+
+	if err != nil {
+		unauthorized(ctx, err)
+		return
+	}
+
+	ctx.Set("user", claims)
+	ctx.Next()
+}
+
+// OptionalAuthJwt behaves like AuthJwt for requests carrying a valid token and
+// lets anonymous requests through, for endpoints that only show more to
+// signed in users
+func OptionalAuthJwt(ctx *gin.Context) {
+	if claims, err := parseToken(ctx.GetHeader("Authorization")); err == nil {
+		ctx.Set("user", claims)
+	}
+
+	ctx.Next()
+}
+
+func parseToken(authorization string) (*dto.ClaimsResponse, error) {
+	reqToken := strings.Split(authorization, "Bearer ")
+
+	if len(reqToken) != 2 {
+		return nil, errors.New("invalid authorization header")
+	}
+
+	claims := &dto.ClaimsResponse{}
+
+	token, err := jwt.ParseWithClaims(reqToken[1], claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+
+		return []byte(os.Getenv("JWT_SECRET")), nil
+	})
+
+	if err != nil || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+// AuthAdmin must run after AuthJwt and rejects tokens not issued to the web-admin client
+func AuthAdmin(ctx *gin.Context) {
+	user, exists := ctx.Get("user")
+
+	if !exists {
+		unauthorized(ctx, errors.New("unauthorized"))
+		return
+	}
+
+	if !user.(*dto.ClaimsResponse).IsAdmin {
+		ctx.JSON(http.StatusForbidden, response.Response(
+			http.StatusForbidden,
+			http.StatusText(http.StatusForbidden),
+			"only admin can access this resource",
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.Next()
+}
+
+// AuthUser must run after AuthJwt and rejects web-admin tokens, whose ID points
+// at the admins table, on routes that treat the token ID as a user
+func AuthUser(ctx *gin.Context) {
+	user, exists := ctx.Get("user")
+
+	if !exists {
+		unauthorized(ctx, errors.New("unauthorized"))
+		return
+	}
+
+	if user.(*dto.ClaimsResponse).IsAdmin {
+		ctx.JSON(http.StatusForbidden, response.Response(
+			http.StatusForbidden,
+			http.StatusText(http.StatusForbidden),
+			"only user can access this resource",
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.Next()
+}
+
+func unauthorized(ctx *gin.Context, err error) {
+	ctx.JSON(http.StatusUnauthorized, response.Response(
+		http.StatusUnauthorized,
+		http.StatusText(http.StatusUnauthorized),
+		err.Error(),
+	))
+	ctx.Abort()
+}
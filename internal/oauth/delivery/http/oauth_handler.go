@@ -4,6 +4,7 @@ import (
 	dto "e-course-management/internal/oauth/dto"
 	usecase "e-course-management/internal/oauth/usecase"
 	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -38,7 +39,9 @@ func (handler *OauthHandler) Login(ctx *gin.Context) {
 	}
 
 	// Kita akan memanggil fungsi dari login
-	data, err := handler.usecase.Login(input)
+	guestCart := utils.GetGuestCart(ctx)
+
+	data, err := handler.usecase.Login(input, guestCart)
 
 	if err != nil {
 		ctx.JSON(int(err.Code), response.Response(
@@ -50,6 +53,10 @@ func (handler *OauthHandler) Login(ctx *gin.Context) {
 		return
 	}
 
+	if data.GuestCartMerged {
+		utils.SetGuestCart(ctx, "")
+	}
+
 	ctx.JSON(http.StatusOK, response.Response(
 		http.StatusOK,
 		http.StatusText(http.StatusOK),
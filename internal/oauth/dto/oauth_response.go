@@ -3,11 +3,12 @@ package oauth
 import "github.com/golang-jwt/jwt/v5"
 
 type LoginResponse struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-	Type         string `json:"Type"`
-	ExpiredAt    string `json:"expired_at"`
-	Scope        string `json:"scope"`
+	AccessToken     string `json:"access_token"`
+	RefreshToken    string `json:"refresh_token"`
+	Type            string `json:"Type"`
+	ExpiredAt       string `json:"expired_at"`
+	Scope           string `json:"scope"`
+	GuestCartMerged bool   `json:"-"`
 }
 
 type UserResponse struct {
@@ -4,6 +4,15 @@
 package oauth
 
 import (
+	cartRepository "e-course-management/internal/cart/repository"
+	cartUseCase "e-course-management/internal/cart/usecase"
+	mediaRepository "e-course-management/internal/media/repository"
+	mediaUseCase "e-course-management/internal/media/usecase"
+	productRepository "e-course-management/internal/product/repository"
+	productUseCase "e-course-management/internal/product/usecase"
+	productCategoryRepository "e-course-management/internal/product_category/repository"
+	productCategoryUseCase "e-course-management/internal/product_category/usecase"
+	"e-course-management/pkg/storage"
 	handler "e-course-management/internal/oauth/delivery/http"
 	oauthRepository "e-course-management/internal/oauth/repository"
 	oauthUseCase "e-course-management/internal/oauth/usecase"
@@ -27,6 +36,15 @@ func InitializedService(db *gorm.DB) *handler.OauthHandler {
 		userRepository.NewUserRepository,
 		adminRepository.NewAdminRepository,
 		adminUseCase.NewAdminUseCase,
+		cartUseCase.NewCartUseCase,
+		cartRepository.NewCartRepository,
+		productUseCase.NewProductUseCase,
+		productRepository.NewProductRepository,
+		productCategoryUseCase.NewProductCategoryUseCase,
+		productCategoryRepository.NewProductCategoryRepository,
+		mediaUseCase.NewMediaUseCase,
+		mediaRepository.NewMediaRepository,
+		storage.NewStorage,
 	)
 
 	return &handler.OauthHandler{}
@@ -9,11 +9,20 @@ package oauth
 import (
 	"e-course-management/internal/admin/repository"
 	admin2 "e-course-management/internal/admin/usecase"
+	"e-course-management/internal/cart/repository"
+	cart2 "e-course-management/internal/cart/usecase"
+	"e-course-management/internal/media/repository"
+	media2 "e-course-management/internal/media/usecase"
 	"e-course-management/internal/oauth/delivery/http"
 	oauth2 "e-course-management/internal/oauth/repository"
 	oauth3 "e-course-management/internal/oauth/usecase"
+	"e-course-management/internal/product/repository"
+	product2 "e-course-management/internal/product/usecase"
+	"e-course-management/internal/product_category/repository"
+	product_category2 "e-course-management/internal/product_category/usecase"
 	"e-course-management/internal/user/repository"
 	user2 "e-course-management/internal/user/usecase"
+	"e-course-management/pkg/storage"
 	"gorm.io/gorm"
 )
 
@@ -27,7 +36,16 @@ func InitializedService(db *gorm.DB) *oauth.OauthHandler {
 	userUseCase := user2.NewUserUseCase(userRepository)
 	adminRepository := admin.NewAdminRepository(db)
 	adminUseCase := admin2.NewAdminUseCase(adminRepository)
-	oauthUseCase := oauth3.NewOauthUseCase(oauthClientRepository, oauthAccessTokenRepository, oauthRefreshTokenRepository, userUseCase, adminUseCase)
+	cartRepository := cart.NewCartRepository(db)
+	productRepository := product.NewProductRepository(db)
+	productCategoryRepository := product_category.NewProductCategoryRepository(db)
+	mediaRepository := media.NewMediaRepository(db)
+	storageStorage := storage.NewStorage()
+	mediaUseCase := media2.NewMediaUseCase(mediaRepository, storageStorage)
+	productCategoryUseCase := product_category2.NewProductCategoryUseCase(productCategoryRepository, mediaUseCase)
+	productUseCase := product2.NewProductUseCase(productRepository, productCategoryUseCase, mediaUseCase)
+	cartUseCase := cart2.NewCartUseCase(db, cartRepository, productUseCase)
+	oauthUseCase := oauth3.NewOauthUseCase(oauthClientRepository, oauthAccessTokenRepository, oauthRefreshTokenRepository, userUseCase, adminUseCase, cartUseCase)
 	oauthHandler := oauth.NewOauthHandler(oauthUseCase)
 	return oauthHandler
 }
@@ -1,6 +1,7 @@
 package oauth
 
 import (
+	cartUseCase "e-course-management/internal/cart/usecase"
 	dto "e-course-management/internal/oauth/dto"
 	entity "e-course-management/internal/oauth/entity"
 	repository "e-course-management/internal/oauth/repository"
@@ -17,7 +18,7 @@ import (
 )
 
 type OauthUseCase interface {
-	Login(dtoLoginRequestBody dto.LoginRequestBody) (*dto.LoginResponse, *response.Error)
+	Login(dtoLoginRequestBody dto.LoginRequestBody, guestCart string) (*dto.LoginResponse, *response.Error)
 	Refresh(dtoRefreshToken dto.RefreshTokenRequestBody) (*dto.LoginResponse, *response.Error)
 }
 
@@ -27,6 +28,7 @@ type oauthUseCase struct {
 	oauthRefreshTokenRepository repository.OauthRefreshTokenRepository
 	userUseCase                 userUseCase.UserUseCase
 	adminUseCase				adminUseCase.AdminUseCase
+	cartUseCase                 cartUseCase.CartUseCase
 }
 
 // Refresh implements OauthUseCase.
@@ -139,7 +141,8 @@ func (usecase *oauthUseCase) Refresh(dtoRefreshToken dto.RefreshTokenRequestBody
 }
 
 // Login implements OauthUseCase.
-func (usecase *oauthUseCase) Login(dtoLoginRequestBody dto.LoginRequestBody) (*dto.LoginResponse, *response.Error) {
+// The guest cart of a user is merged into their cart.
+func (usecase *oauthUseCase) Login(dtoLoginRequestBody dto.LoginRequestBody, guestCart string) (*dto.LoginResponse, *response.Error) {
 	oauthClient, err := usecase.oauthClientRepository.FindByClientIDAndClientSecret(
 		dtoLoginRequestBody.ClientID,
 		dtoLoginRequestBody.ClientSecret,
@@ -247,13 +250,20 @@ func (usecase *oauthUseCase) Login(dtoLoginRequestBody dto.LoginRequestBody) (*d
 		return nil, err
 	}
 
-	return &dto.LoginResponse{
+	result := &dto.LoginResponse{
 		AccessToken:  oauthAccessToken.Token,
 		RefreshToken: oauthRefreshToken.Token,
 		Type:         "Bearer",
 		ExpiredAt:    expirationTime.Format(time.RFC3339),
 		Scope:        "*",
-	}, nil
+	}
+
+	// A failed merge leaves the guest cart in place for the next login
+	if oauthClient.Name != "web-admin" && guestCart != "" {
+		result.GuestCartMerged = usecase.cartUseCase.MergeGuest(guestCart, user.ID) == nil
+	}
+
+	return result, nil
 }
 
 func NewOauthUseCase(
@@ -262,6 +272,7 @@ func NewOauthUseCase(
 	oauthRefreshTokenRepository repository.OauthRefreshTokenRepository,
 	userUseCase userUseCase.UserUseCase,
 	adminUseCase adminUseCase.AdminUseCase,
+	cartUseCase cartUseCase.CartUseCase,
 ) OauthUseCase {
 	return &oauthUseCase{
 		oauthClientRepository,
@@ -269,5 +280,6 @@ func NewOauthUseCase(
 		oauthRefreshTokenRepository,
 		userUseCase,
 		adminUseCase,
+		cartUseCase,
 	}
 }
@@ -0,0 +1,231 @@
+package order
+
+import (
+	"net/http"
+	"strconv"
+
+	"e-course-management/internal/middleware"
+	dto "e-course-management/internal/order/dto"
+	usecase "e-course-management/internal/order/usecase"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrderHandler struct {
+	usecase usecase.OrderUseCase
+}
+
+func NewOrderHandler(usecase usecase.OrderUseCase) *OrderHandler {
+	return &OrderHandler{usecase}
+}
+
+func (handler *OrderHandler) Route(r *gin.RouterGroup) {
+	orderRouter := r.Group("/api/v1")
+
+	orderRouter.POST("/payments/callback", handler.PaymentCallback)
+	orderRouter.POST("/checkouts", middleware.AuthJwt, middleware.AuthUser, handler.Checkout)
+	orderRouter.GET("/orders", middleware.AuthJwt, middleware.AuthUser, handler.FindAllMine)
+	orderRouter.GET("/orders/:id", middleware.AuthJwt, middleware.AuthUser, handler.FindMine)
+	orderRouter.GET("/orders/:id/histories", middleware.AuthJwt, handler.FindHistories)
+	orderRouter.POST("/orders/:id/cancel", middleware.AuthJwt, middleware.AuthUser, handler.Cancel)
+
+	orderRouter.Use(middleware.AuthJwt, middleware.AuthAdmin)
+	{
+		orderRouter.PATCH("/orders/:id/status", handler.UpdateStatus)
+	}
+}
+
+func (handler *OrderHandler) Checkout(ctx *gin.Context) {
+	var input dto.CheckoutRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+
+	input.PayerEmail = user.Email
+
+	data, err := handler.usecase.Checkout(user.ID, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Response(
+		http.StatusCreated,
+		http.StatusText(http.StatusCreated),
+		data,
+	))
+}
+
+func (handler *OrderHandler) FindAllMine(ctx *gin.Context) {
+	offset, _ := strconv.Atoi(ctx.Query("offset"))
+	limit, _ := strconv.Atoi(ctx.Query("limit"))
+
+	user := utils.GetCurrentUser(ctx)
+
+	data := handler.usecase.FindAllMine(user.ID, offset, limit)
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *OrderHandler) FindMine(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.FindMine(id, user.ID)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *OrderHandler) PaymentCallback(ctx *gin.Context) {
+	payload, errRead := ctx.GetRawData()
+
+	if errRead != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			errRead.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	err := handler.usecase.PaymentCallback(ctx.Request.Header, payload)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		"ok",
+	))
+}
+
+func (handler *OrderHandler) FindHistories(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.FindHistories(id, user)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *OrderHandler) Cancel(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	user := utils.GetCurrentUser(ctx)
+
+	data, err := handler.usecase.Cancel(id, user.ID)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
+
+func (handler *OrderHandler) UpdateStatus(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var input dto.OrderStatusRequestBody
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Response(
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	user := utils.GetCurrentUser(ctx)
+
+	input.UpdatedBy = &user.ID
+
+	data, err := handler.usecase.UpdateStatus(id, input)
+
+	if err != nil {
+		ctx.JSON(int(err.Code), response.Response(
+			int(err.Code),
+			http.StatusText(int(err.Code)),
+			err.Err.Error(),
+		))
+		ctx.Abort()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Response(
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		data,
+	))
+}
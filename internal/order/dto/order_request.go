@@ -0,0 +1,20 @@
+package order
+
+import (
+	entity "e-course-management/internal/order/entity"
+)
+
+// CheckoutRequestBody holds the optional discount codes of a checkout, see
+// DiscountValidateRequestBody for how several codes combine
+type CheckoutRequestBody struct {
+	DiscountCodes []string `json:"discount_codes" binding:"max=5,dive,required"`
+	PayerEmail    string   `json:"-"`
+}
+
+// OrderStatusRequestBody moves an order to another status, see
+// Status.CanTransitionTo for the allowed moves
+type OrderStatusRequestBody struct {
+	Status    entity.Status `json:"status" binding:"required"`
+	Reason    string        `json:"reason" binding:"max=255"`
+	UpdatedBy *int64        `json:"-"`
+}
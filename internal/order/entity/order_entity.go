@@ -0,0 +1,72 @@
+package order
+
+import (
+	orderDetail "e-course-management/internal/order_detail/entity"
+	user "e-course-management/internal/user/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Status is the stage of an order, see CanTransitionTo for how it moves
+type Status string
+
+const (
+	StatusPending           Status = "pending"
+	StatusPaid              Status = "paid"
+	StatusExpired           Status = "expired"
+	StatusCancelled         Status = "cancelled"
+	StatusRefunded          Status = "refunded"
+	StatusPartiallyRefunded Status = "partially_refunded"
+)
+
+// transitions lists where each status may move to. Expired, cancelled and
+// refunded orders are final, partial refunds may be followed by more.
+var transitions = map[Status][]Status{
+	StatusPending:           {StatusPaid, StatusExpired, StatusCancelled},
+	StatusPaid:              {StatusRefunded, StatusPartiallyRefunded},
+	StatusPartiallyRefunded: {StatusPartiallyRefunded, StatusRefunded},
+}
+
+// IsValid tells whether status is one of the known statuses
+func (status Status) IsValid() bool {
+	switch status {
+	case StatusPending, StatusPaid, StatusExpired, StatusCancelled, StatusRefunded, StatusPartiallyRefunded:
+		return true
+	}
+
+	return false
+}
+
+// CanTransitionTo tells whether an order may move from status to next
+func (status Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range transitions[status] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Order struct {
+	ID                    int64                     `json:"id"`
+	User                  *user.User                `json:"user" gorm:"foreignKey:UserID;references:ID"`
+	UserID                *int64                    `json:"user_id"`
+	DiscountID            *int64                    `json:"discount_id"`
+	OrderDetails          []orderDetail.OrderDetail `json:"order_details"`
+	CheckoutLink          *string                   `json:"checkout_link"`
+	ExternalID            *string                   `json:"external_id"`
+	Price                 int64                     `json:"price"`
+	TotalPrice            int64                     `json:"total_price"`
+	Status                Status                    `json:"status"`
+	PaymentReminderSentAt *time.Time                `json:"payment_reminder_sent_at"`
+	ExpiryAttempts        int                       `json:"-"`
+	NextExpiryAttemptAt   *time.Time                `json:"-"`
+	InvoiceClosedAt       *time.Time                `json:"-"`
+	CreatedByID           *int64                    `json:"created_by" gorm:"column:created_by"`
+	UpdatedByID           *int64                    `json:"updated_by" gorm:"column:updated_by"`
+	CreatedAt             *time.Time                `json:"created_at"`
+	UpdatedAt             *time.Time                `json:"updated_at"`
+	DeletedAt             gorm.DeletedAt            `json:"deleted_at"`
+}
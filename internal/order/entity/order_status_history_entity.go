@@ -0,0 +1,27 @@
+package order
+
+import (
+	"time"
+)
+
+const (
+	SourceUser    = "user"
+	SourceAdmin   = "admin"
+	SourcePayment = "payment"
+	SourceSystem  = "system"
+)
+
+// OrderStatusHistory records one status change of an order. Source tells
+// what made the change. A buyer is kept in ChangedByID and an admin in
+// ChangedByAdminID, as they live in different tables.
+type OrderStatusHistory struct {
+	ID               int64      `json:"id"`
+	OrderID          int64      `json:"order_id"`
+	FromStatus       *Status    `json:"from_status"`
+	ToStatus         Status     `json:"to_status"`
+	Source           string     `json:"source"`
+	Reason           *string    `json:"reason"`
+	ChangedByID      *int64     `json:"changed_by" gorm:"column:changed_by"`
+	ChangedByAdminID *int64     `json:"changed_by_admin" gorm:"column:changed_by_admin"`
+	CreatedAt        *time.Time `json:"created_at"`
+}
@@ -0,0 +1,87 @@
+//go:build wireinject
+// +build wireinject
+
+package order
+
+import (
+	cartRepository "e-course-management/internal/cart/repository"
+	cartUseCase "e-course-management/internal/cart/usecase"
+	classRoomRepository "e-course-management/internal/class_room/repository"
+	classRoomUseCase "e-course-management/internal/class_room/usecase"
+	discountRepository "e-course-management/internal/discount/repository"
+	discountUseCase "e-course-management/internal/discount/usecase"
+	emailOutboxRepository "e-course-management/internal/email_outbox/repository"
+	emailSuppressionRepository "e-course-management/internal/email_suppression/repository"
+	mediaRepository "e-course-management/internal/media/repository"
+	mediaUseCase "e-course-management/internal/media/usecase"
+	handler "e-course-management/internal/order/delivery/http"
+	repository "e-course-management/internal/order/repository"
+	usecase "e-course-management/internal/order/usecase"
+	orderNotificationRepository "e-course-management/internal/order_notification/repository"
+	orderNotificationUseCase "e-course-management/internal/order_notification/usecase"
+	productRepository "e-course-management/internal/product/repository"
+	productUseCase "e-course-management/internal/product/usecase"
+	productCategoryRepository "e-course-management/internal/product_category/repository"
+	productCategoryUseCase "e-course-management/internal/product_category/usecase"
+	mail "e-course-management/pkg/mail/sendgrid"
+	"e-course-management/pkg/payment"
+	"e-course-management/pkg/storage"
+
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+func InitializedService(db *gorm.DB, payment payment.Payment) *handler.OrderHandler {
+	wire.Build(
+		handler.NewOrderHandler,
+		usecase.NewOrderUseCase,
+		repository.NewOrderRepository,
+		discountUseCase.NewDiscountUseCase,
+		discountRepository.NewDiscountRepository,
+		cartUseCase.NewCartUseCase,
+		cartRepository.NewCartRepository,
+		productUseCase.NewProductUseCase,
+		productRepository.NewProductRepository,
+		productCategoryUseCase.NewProductCategoryUseCase,
+		productCategoryRepository.NewProductCategoryRepository,
+		mediaUseCase.NewMediaUseCase,
+		mediaRepository.NewMediaRepository,
+		storage.NewStorage,
+		classRoomUseCase.NewClassRoomUseCase,
+		classRoomRepository.NewClassRoomRepository,
+		orderNotificationUseCase.NewOrderNotificationUseCase,
+		orderNotificationRepository.NewOrderNotificationRepository,
+		mail.NewMailUseCase,
+		emailOutboxRepository.NewEmailOutboxRepository,
+		emailSuppressionRepository.NewEmailSuppressionRepository,
+	)
+
+	return &handler.OrderHandler{}
+}
+
+func InitializedWorker(db *gorm.DB, payment payment.Payment) usecase.OrderUseCase {
+	wire.Build(
+		usecase.NewOrderUseCase,
+		repository.NewOrderRepository,
+		discountUseCase.NewDiscountUseCase,
+		discountRepository.NewDiscountRepository,
+		cartUseCase.NewCartUseCase,
+		cartRepository.NewCartRepository,
+		productUseCase.NewProductUseCase,
+		productRepository.NewProductRepository,
+		productCategoryUseCase.NewProductCategoryUseCase,
+		productCategoryRepository.NewProductCategoryRepository,
+		mediaUseCase.NewMediaUseCase,
+		mediaRepository.NewMediaRepository,
+		storage.NewStorage,
+		classRoomUseCase.NewClassRoomUseCase,
+		classRoomRepository.NewClassRoomRepository,
+		orderNotificationUseCase.NewOrderNotificationUseCase,
+		orderNotificationRepository.NewOrderNotificationRepository,
+		mail.NewMailUseCase,
+		emailOutboxRepository.NewEmailOutboxRepository,
+		emailSuppressionRepository.NewEmailSuppressionRepository,
+	)
+
+	return nil
+}
@@ -0,0 +1,272 @@
+package order
+
+import (
+	"errors"
+	"time"
+
+	entity "e-course-management/internal/order/entity"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrderRepository interface {
+	FindAllByUserId(userID int64, offset int, limit int) []entity.Order
+	FindAllPendingBefore(createdBefore time.Time, limit int) []entity.Order
+	FindAllWithOpenInvoice(limit int) []entity.Order
+	DelayExpiry(id int64, attempts int, nextAttemptAt time.Time) *response.Error
+	FindOneById(id int) (*entity.Order, *response.Error)
+	FindOneByExternalId(externalID string) (*entity.Order, *response.Error)
+	FindHistoriesByOrderId(orderID int64) []entity.OrderStatusHistory
+	LockOneById(id int) (*entity.Order, *response.Error)
+	MarkInvoiceClosed(id int64) *response.Error
+	Create(order entity.Order) (*entity.Order, *response.Error)
+	CreateHistory(history entity.OrderStatusHistory) *response.Error
+	UpdateCheckout(id int64, checkoutLink string, externalID string) *response.Error
+	UpdateStatus(id int64, from entity.Status, to entity.Status) (bool, *response.Error)
+	WithTx(tx *gorm.DB) OrderRepository
+}
+
+type orderRepository struct {
+	db *gorm.DB
+}
+
+// Create implements OrderRepository.
+// The order details are created along with the order.
+func (repository *orderRepository) Create(order entity.Order) (*entity.Order, *response.Error) {
+	if err := repository.db.Create(&order).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &order, nil
+}
+
+// CreateHistory implements OrderRepository.
+func (repository *orderRepository) CreateHistory(history entity.OrderStatusHistory) *response.Error {
+	if err := repository.db.Create(&history).Error; err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// DelayExpiry implements OrderRepository.
+func (repository *orderRepository) DelayExpiry(id int64, attempts int, nextAttemptAt time.Time) *response.Error {
+	err := repository.db.Model(&entity.Order{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"expiry_attempts":        attempts,
+			"next_expiry_attempt_at": nextAttemptAt,
+		}).
+		Error
+
+	if err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// FindAllByUserId implements OrderRepository.
+func (repository *orderRepository) FindAllByUserId(userID int64, offset int, limit int) []entity.Order {
+	var orders []entity.Order
+
+	repository.db.
+		Scopes(utils.Paginate(offset, limit)).
+		Preload("OrderDetails.Product").
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Find(&orders)
+
+	return orders
+}
+
+// FindAllPendingBefore implements OrderRepository.
+// Orders whose expiry failed are left out until their next attempt is due.
+func (repository *orderRepository) FindAllPendingBefore(createdBefore time.Time, limit int) []entity.Order {
+	var orders []entity.Order
+
+	repository.db.
+		Where("status = ? AND created_at <= ?", entity.StatusPending, createdBefore).
+		Where("next_expiry_attempt_at IS NULL OR next_expiry_attempt_at <= ?", time.Now()).
+		Order("created_at, id").
+		Limit(limit).
+		Find(&orders)
+
+	return orders
+}
+
+// FindAllWithOpenInvoice implements OrderRepository.
+// These are expired or cancelled orders whose invoice could not be closed
+// yet, the ones backing off are left out until their next attempt.
+func (repository *orderRepository) FindAllWithOpenInvoice(limit int) []entity.Order {
+	var orders []entity.Order
+
+	repository.db.
+		Where("status IN ?", []entity.Status{entity.StatusExpired, entity.StatusCancelled}).
+		Where("external_id IS NOT NULL AND invoice_closed_at IS NULL").
+		Where("next_expiry_attempt_at IS NULL OR next_expiry_attempt_at <= ?", time.Now()).
+		Order("updated_at, id").
+		Limit(limit).
+		Find(&orders)
+
+	return orders
+}
+
+// FindHistoriesByOrderId implements OrderRepository.
+func (repository *orderRepository) FindHistoriesByOrderId(orderID int64) []entity.OrderStatusHistory {
+	var histories []entity.OrderStatusHistory
+
+	repository.db.
+		Where("order_id = ?", orderID).
+		Order("created_at, id").
+		Find(&histories)
+
+	return histories
+}
+
+// FindOneByExternalId implements OrderRepository.
+// The order is locked until the end of the transaction.
+func (repository *orderRepository) FindOneByExternalId(externalID string) (*entity.Order, *response.Error) {
+	var order entity.Order
+
+	err := repository.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("external_id = ?", externalID).
+		First(&order).
+		Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("order not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &order, nil
+}
+
+// FindOneById implements OrderRepository.
+func (repository *orderRepository) FindOneById(id int) (*entity.Order, *response.Error) {
+	var order entity.Order
+
+	if err := repository.db.Preload("OrderDetails.Product").First(&order, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("order not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &order, nil
+}
+
+// LockOneById implements OrderRepository.
+// The order is locked until the end of the transaction.
+func (repository *orderRepository) LockOneById(id int) (*entity.Order, *response.Error) {
+	var order entity.Order
+
+	if err := repository.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("order not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &order, nil
+}
+
+// MarkInvoiceClosed implements OrderRepository.
+func (repository *orderRepository) MarkInvoiceClosed(id int64) *response.Error {
+	err := repository.db.Model(&entity.Order{}).
+		Where("id = ?", id).
+		Update("invoice_closed_at", time.Now()).
+		Error
+
+	if err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// UpdateCheckout implements OrderRepository.
+func (repository *orderRepository) UpdateCheckout(id int64, checkoutLink string, externalID string) *response.Error {
+	err := repository.db.Model(&entity.Order{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"checkout_link": checkoutLink,
+			"external_id":   externalID,
+		}).
+		Error
+
+	if err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// UpdateStatus implements OrderRepository.
+// The status only changes while it is still from, and the result tells
+// whether this call made the change.
+func (repository *orderRepository) UpdateStatus(id int64, from entity.Status, to entity.Status) (bool, *response.Error) {
+	result := repository.db.Model(&entity.Order{}).
+		Where("id = ? AND status = ?", id, from).
+		Update("status", to)
+
+	if result.Error != nil {
+		return false, &response.Error{
+			Code: 500,
+			Err:  result.Error,
+		}
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// WithTx implements OrderRepository.
+func (repository *orderRepository) WithTx(tx *gorm.DB) OrderRepository {
+	return &orderRepository{tx}
+}
+
+func NewOrderRepository(db *gorm.DB) OrderRepository {
+	return &orderRepository{db}
+}
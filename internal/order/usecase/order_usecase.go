@@ -0,0 +1,695 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	cartUseCase "e-course-management/internal/cart/usecase"
+	classRoomUseCase "e-course-management/internal/class_room/usecase"
+	discountDto "e-course-management/internal/discount/dto"
+	discountUseCase "e-course-management/internal/discount/usecase"
+	oauthDto "e-course-management/internal/oauth/dto"
+	dto "e-course-management/internal/order/dto"
+	entity "e-course-management/internal/order/entity"
+	repository "e-course-management/internal/order/repository"
+	orderDetailEntity "e-course-management/internal/order_detail/entity"
+	orderNotificationUseCase "e-course-management/internal/order_notification/usecase"
+	mysql "e-course-management/pkg/db/mysql"
+	"e-course-management/pkg/payment"
+	"e-course-management/pkg/response"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPaymentDuration = 24 * time.Hour
+	expiryBatchSize        = 50
+	expiryGrace            = time.Hour
+	expiryInterval         = time.Minute
+	expiryLockName         = "order_expiry"
+	maxExpiryBackoff       = 6 * time.Hour
+)
+
+type OrderUseCase interface {
+	FindAllMine(userID int64, offset int, limit int) []entity.Order
+	FindMine(id int, userID int64) (*entity.Order, *response.Error)
+	FindHistories(id int, user *oauthDto.ClaimsResponse) ([]entity.OrderStatusHistory, *response.Error)
+	Checkout(userID int64, request dto.CheckoutRequestBody) (*entity.Order, *response.Error)
+	Cancel(id int, userID int64) (*entity.Order, *response.Error)
+	UpdateStatus(id int, request dto.OrderStatusRequestBody) (*entity.Order, *response.Error)
+	PaymentCallback(header http.Header, body []byte) *response.Error
+	ExpirePendingOrders() int
+	Run()
+}
+
+type orderUseCase struct {
+	db                       *gorm.DB
+	repository               repository.OrderRepository
+	cartUseCase              cartUseCase.CartUseCase
+	discountUseCase          discountUseCase.DiscountUseCase
+	classRoomUseCase         classRoomUseCase.ClassRoomUseCase
+	orderNotificationUseCase orderNotificationUseCase.OrderNotificationUseCase
+	payment                  payment.Payment
+}
+
+// Cancel implements OrderUseCase.
+// Buyers can only cancel orders that are still waiting for payment.
+func (usecase *orderUseCase) Cancel(id int, userID int64) (*entity.Order, *response.Error) {
+	errTx := usecase.db.Transaction(func(tx *gorm.DB) error {
+		order, err := usecase.repository.WithTx(tx).LockOneById(id)
+
+		if err != nil {
+			return err
+		}
+
+		if order.UserID == nil || *order.UserID != userID {
+			return &response.Error{
+				Code: 404,
+				Err:  errors.New("order not found"),
+			}
+		}
+
+		if err := usecase.transition(tx, *order, entity.StatusCancelled, entity.SourceUser, "cancelled by the buyer", &userID); err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	if errTx != nil {
+		return nil, response.FromError(errTx)
+	}
+
+	return usecase.settled(id)
+}
+
+// Checkout implements OrderUseCase.
+// In one transaction the checked courses in the cart are copied into order
+// details at their current price, the discounts are redeemed, the pending
+// order is created and the bought courses leave the cart. Orders discounted
+// to zero are paid straight away, the others get their invoice once the
+// transaction is committed, see openInvoice.
+func (usecase *orderUseCase) Checkout(userID int64, request dto.CheckoutRequestBody) (*entity.Order, *response.Error) {
+	var checkedOut *entity.Order
+
+	errTx := usecase.db.Transaction(func(tx *gorm.DB) error {
+		txCartUseCase := usecase.cartUseCase.WithTx(tx)
+		txDiscountUseCase := usecase.discountUseCase.WithTx(tx)
+
+		carts := txCartUseCase.FindChecked(userID)
+
+		if len(carts) == 0 {
+			return &response.Error{
+				Code: 400,
+				Err:  errors.New("there are no checked courses in your cart"),
+			}
+		}
+
+		order := entity.Order{
+			UserID:      &userID,
+			Status:      entity.StatusPending,
+			CreatedByID: &userID,
+		}
+
+		var items []discountDto.DiscountItem
+
+		for _, cart := range carts {
+			if txCartUseCase.IsOwned(userID, cart.Product.ID) {
+				return &response.Error{
+					Code: 409,
+					Err:  fmt.Errorf("you already own %s, please remove it from your cart", cart.Product.Title),
+				}
+			}
+
+			productID := cart.Product.ID
+
+			order.OrderDetails = append(order.OrderDetails, orderDetailEntity.OrderDetail{
+				ProductID:   &productID,
+				Price:       cart.Product.Price,
+				CreatedByID: &userID,
+			})
+
+			items = append(items, discountDto.DiscountItem{
+				ProductID:         cart.Product.ID,
+				ProductCategoryID: cart.Product.ProductCategoryID,
+				Price:             cart.Product.Price,
+			})
+
+			order.Price += cart.Product.Price
+		}
+
+		order.TotalPrice = order.Price
+
+		var discounts []discountDto.AppliedDiscount
+
+		if len(request.DiscountCodes) > 0 {
+			calculation, err := txDiscountUseCase.Calculate(request.DiscountCodes, userID, items)
+
+			if err != nil {
+				return err
+			}
+
+			discounts = calculation.Discounts
+			order.TotalPrice = calculation.Total
+			order.DiscountID = &discounts[0].DiscountID
+		}
+
+		created, err := usecase.repository.WithTx(tx).Create(order)
+
+		if err != nil {
+			return err
+		}
+
+		if err := usecase.repository.WithTx(tx).CreateHistory(entity.OrderStatusHistory{
+			OrderID:     created.ID,
+			ToStatus:    entity.StatusPending,
+			Source:      entity.SourceUser,
+			ChangedByID: &userID,
+		}); err != nil {
+			return err
+		}
+
+		for _, discount := range discounts {
+			if err := txDiscountUseCase.Redeem(discount.DiscountID, created.ID, &userID); err != nil {
+				return err
+			}
+		}
+
+		if err := txCartUseCase.DeleteAll(carts); err != nil {
+			return err
+		}
+
+		checkedOut = created
+
+		if created.TotalPrice == 0 {
+			if err := usecase.transition(tx, *created, entity.StatusPaid, entity.SourceSystem, "nothing to pay", nil); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if errTx != nil {
+		return nil, response.FromError(errTx)
+	}
+
+	if checkedOut.TotalPrice > 0 {
+		if err := usecase.openInvoice(*checkedOut, request.PayerEmail); err != nil {
+			return nil, err
+		}
+	}
+
+	return usecase.repository.FindOneById(int(checkedOut.ID))
+}
+
+// ExpirePendingOrders implements OrderUseCase.
+// It expires one batch of orders left unpaid for longer than the TTL and
+// returns how many were expired. Orders that fail to expire stay pending and
+// are tried again later, backing off so that they do not hold up the orders
+// behind them.
+func (usecase *orderUseCase) ExpirePendingOrders() int {
+	orders := usecase.repository.FindAllPendingBefore(time.Now().Add(-pendingTTL()), expiryBatchSize)
+
+	expired := 0
+
+	for _, pending := range orders {
+		errTx := usecase.db.Transaction(func(tx *gorm.DB) error {
+			order, err := usecase.repository.WithTx(tx).LockOneById(int(pending.ID))
+
+			if err != nil {
+				return err
+			}
+
+			if order.Status != entity.StatusPending {
+				return nil
+			}
+
+			if err := usecase.transition(tx, *order, entity.StatusExpired, entity.SourceSystem, "not paid in time", nil); err != nil {
+				return err
+			}
+
+			expired++
+
+			return nil
+		})
+
+		if errTx != nil {
+			fmt.Println(errTx)
+			usecase.delayExpiry(pending)
+
+			continue
+		}
+
+		if _, err := usecase.settled(int(pending.ID)); err != nil {
+			fmt.Println(err.Err)
+		}
+	}
+
+	return expired
+}
+
+// FindAllMine implements OrderUseCase.
+func (usecase *orderUseCase) FindAllMine(userID int64, offset int, limit int) []entity.Order {
+	return usecase.repository.FindAllByUserId(userID, offset, limit)
+}
+
+// FindHistories implements OrderUseCase.
+// Admins see the timeline of any order, buyers only of their own.
+func (usecase *orderUseCase) FindHistories(id int, user *oauthDto.ClaimsResponse) ([]entity.OrderStatusHistory, *response.Error) {
+	var order *entity.Order
+	var err *response.Error
+
+	if user.IsAdmin {
+		order, err = usecase.repository.FindOneById(id)
+	} else {
+		order, err = usecase.FindMine(id, user.ID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return usecase.repository.FindHistoriesByOrderId(order.ID), nil
+}
+
+// FindMine implements OrderUseCase.
+func (usecase *orderUseCase) FindMine(id int, userID int64) (*entity.Order, *response.Error) {
+	order, err := usecase.repository.FindOneById(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if order.UserID == nil || *order.UserID != userID {
+		return nil, &response.Error{
+			Code: 404,
+			Err:  errors.New("order not found"),
+		}
+	}
+
+	return order, nil
+}
+
+// PaymentCallback implements OrderUseCase.
+// Callbacks are matched to orders by the invoice id. Only pending orders
+// move, so provider retries and late callbacks are acknowledged and ignored.
+func (usecase *orderUseCase) PaymentCallback(header http.Header, body []byte) *response.Error {
+	callback, errCallback := usecase.payment.ParseCallback(header, body)
+
+	if errors.Is(errCallback, payment.ErrInvalidCallbackToken) {
+		return &response.Error{
+			Code: 401,
+			Err:  errCallback,
+		}
+	}
+
+	if errCallback != nil {
+		return &response.Error{
+			Code: 400,
+			Err:  errCallback,
+		}
+	}
+
+	if callback.Status == "" {
+		return nil
+	}
+
+	errTx := usecase.db.Transaction(func(tx *gorm.DB) error {
+		order, err := usecase.repository.WithTx(tx).FindOneByExternalId(callback.InvoiceID)
+
+		if err != nil {
+			return err
+		}
+
+		if order.Status != entity.StatusPending {
+			return nil
+		}
+
+		if callback.Status == payment.StatusPaid && callback.Amount < order.TotalPrice {
+			return &response.Error{
+				Code: 400,
+				Err:  errors.New("paid amount does not match the order"),
+			}
+		}
+
+		status, reason := entity.StatusPaid, "payment received"
+
+		switch callback.Status {
+		case payment.StatusExpired:
+			status, reason = entity.StatusExpired, "invoice expired"
+		case payment.StatusFailed:
+			status, reason = entity.StatusCancelled, "payment failed"
+		}
+
+		if err := usecase.transition(tx, *order, status, entity.SourcePayment, reason, nil); err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	return response.FromError(errTx)
+}
+
+// Run implements OrderUseCase.
+// Every replica runs it, but only the one holding the MySQL lock expires
+// orders and retries closing the invoices that are still open. The lock is kept between runs and taken over by another replica
+// once its holder goes away.
+func (usecase *orderUseCase) Run() {
+	ticker := time.NewTicker(expiryInterval)
+	defer ticker.Stop()
+
+	var lock *mysql.Lock
+
+	for range ticker.C {
+		if lock != nil && !lock.Held() {
+			lock.Release()
+			lock = nil
+		}
+
+		if lock == nil {
+			acquired, err := mysql.TryLock(usecase.db, expiryLockName)
+
+			if err != nil {
+				fmt.Println(err)
+			}
+
+			if acquired == nil {
+				continue
+			}
+
+			lock = acquired
+		}
+
+		usecase.ExpirePendingOrders()
+		usecase.closeInvoices()
+	}
+}
+
+// UpdateStatus implements OrderUseCase.
+func (usecase *orderUseCase) UpdateStatus(id int, request dto.OrderStatusRequestBody) (*entity.Order, *response.Error) {
+	if !request.Status.IsValid() {
+		return nil, &response.Error{
+			Code: 400,
+			Err:  fmt.Errorf("unknown order status %s", request.Status),
+		}
+	}
+
+	errTx := usecase.db.Transaction(func(tx *gorm.DB) error {
+		order, err := usecase.repository.WithTx(tx).LockOneById(id)
+
+		if err != nil {
+			return err
+		}
+
+		if err := usecase.transition(tx, *order, request.Status, entity.SourceAdmin, request.Reason, request.UpdatedBy); err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	if errTx != nil {
+		return nil, response.FromError(errTx)
+	}
+
+	return usecase.settled(id)
+}
+
+// openInvoice opens the invoice of a committed pending order. It runs
+// outside of the checkout transaction so that no locks are held while the
+// provider is called, and no invoice exists for an order that was rolled
+// back. When no invoice can be attached the order is cancelled, which gives
+// its discounts back.
+func (usecase *orderUseCase) openInvoice(order entity.Order, payerEmail string) *response.Error {
+	invoice, errInvoice := usecase.payment.CreateInvoice(payment.InvoiceRequest{
+		ExternalID:  fmt.Sprintf("order-%d", order.ID),
+		Amount:      order.TotalPrice,
+		PayerEmail:  payerEmail,
+		Description: fmt.Sprintf("Order #%d", order.ID),
+		Duration:    paymentDuration(),
+	})
+
+	if errInvoice == nil {
+		err := usecase.repository.UpdateCheckout(order.ID, invoice.URL, invoice.ID)
+
+		if err == nil {
+			return nil
+		}
+
+		// The invoice is unknown to us, so it must not stay payable
+		usecase.payment.ExpireInvoice(invoice.ID)
+
+		errInvoice = err.Err
+	}
+
+	errTx := usecase.db.Transaction(func(tx *gorm.DB) error {
+		locked, err := usecase.repository.WithTx(tx).LockOneById(int(order.ID))
+
+		if err != nil {
+			return err
+		}
+
+		if err := usecase.transition(tx, *locked, entity.StatusCancelled, entity.SourceSystem, "invoice could not be opened", nil); err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	if errTx != nil {
+		fmt.Println(errTx)
+	}
+
+	return &response.Error{
+		Code: 502,
+		Err:  fmt.Errorf("payment could not be started: %w", errInvoice),
+	}
+}
+
+// transition is the only way an order changes status. Illegal moves are
+// rejected and every change is recorded with its source and reason.
+// changedBy is an admin when source is SourceAdmin and a user otherwise.
+// Orders that are given up give their discounts back, their invoice is
+// closed by settled once the transaction is committed, unless the provider
+// closed it itself. Paid orders enroll the buyer into the courses before
+// notifying them.
+func (usecase *orderUseCase) transition(
+	tx *gorm.DB,
+	order entity.Order,
+	status entity.Status,
+	source string,
+	reason string,
+	changedBy *int64,
+) *response.Error {
+	if !order.Status.CanTransitionTo(status) {
+		return &response.Error{
+			Code: 409,
+			Err:  fmt.Errorf("order cannot move from %s to %s", order.Status, status),
+		}
+	}
+
+	txRepository := usecase.repository.WithTx(tx)
+
+	if status != order.Status {
+		changed, err := txRepository.UpdateStatus(order.ID, order.Status, status)
+
+		if err != nil {
+			return err
+		}
+
+		if !changed {
+			return &response.Error{
+				Code: 409,
+				Err:  errors.New("order status has changed, please try again"),
+			}
+		}
+	}
+
+	history := entity.OrderStatusHistory{
+		OrderID:    order.ID,
+		FromStatus: &order.Status,
+		ToStatus:   status,
+		Source:     source,
+	}
+
+	if source == entity.SourceAdmin {
+		history.ChangedByAdminID = changedBy
+	} else {
+		history.ChangedByID = changedBy
+	}
+
+	if reason != "" {
+		history.Reason = &reason
+	}
+
+	if err := txRepository.CreateHistory(history); err != nil {
+		return err
+	}
+
+	switch status {
+	case entity.StatusExpired, entity.StatusCancelled:
+		if source == entity.SourcePayment && order.ExternalID != nil {
+			if err := txRepository.MarkInvoiceClosed(order.ID); err != nil {
+				return err
+			}
+		}
+
+		return usecase.discountUseCase.WithTx(tx).Release(order.ID)
+	case entity.StatusPaid:
+		if err := usecase.enroll(tx, order.ID); err != nil {
+			return err
+		}
+
+		return usecase.orderNotificationUseCase.WithTx(tx).OrderStatusChanged(order.ID, status)
+	}
+
+	return nil
+}
+
+// settled reloads an order after its transaction is committed and closes
+// the invoice of an order that was given up. The provider is called outside
+// of the transaction so that no locks are held meanwhile, and an invoice is
+// only closed for a status change that was committed.
+func (usecase *orderUseCase) settled(id int) (*entity.Order, *response.Error) {
+	order, err := usecase.repository.FindOneById(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	usecase.closeInvoice(*order)
+
+	return order, nil
+}
+
+// closeInvoice expires the open invoice of an expired or cancelled order.
+// Failures are logged and retried by the worker with a backoff, see
+// closeInvoices. An invoice paid in the meantime cannot be closed anymore,
+// the payment has to be refunded by hand.
+func (usecase *orderUseCase) closeInvoice(order entity.Order) {
+	if order.Status != entity.StatusExpired && order.Status != entity.StatusCancelled {
+		return
+	}
+
+	if order.ExternalID == nil || order.InvoiceClosedAt != nil {
+		return
+	}
+
+	errInvoice := usecase.payment.ExpireInvoice(*order.ExternalID)
+
+	if errors.Is(errInvoice, payment.ErrInvoicePaid) {
+		fmt.Printf("order %d is %s but its invoice %s was paid, refund the payment\n", order.ID, order.Status, *order.ExternalID)
+	} else if errInvoice != nil {
+		fmt.Println(errInvoice)
+		usecase.delayExpiry(order)
+
+		return
+	}
+
+	if err := usecase.repository.MarkInvoiceClosed(order.ID); err != nil {
+		fmt.Println(err.Err)
+	}
+}
+
+// closeInvoices retries closing one batch of invoices that are still open
+func (usecase *orderUseCase) closeInvoices() {
+	for _, order := range usecase.repository.FindAllWithOpenInvoice(expiryBatchSize) {
+		usecase.closeInvoice(order)
+	}
+}
+
+// delayExpiry backs off the next attempt to expire the order or close its
+// invoice
+func (usecase *orderUseCase) delayExpiry(order entity.Order) {
+	attempts := order.ExpiryAttempts + 1
+
+	if err := usecase.repository.DelayExpiry(order.ID, attempts, time.Now().Add(expiryBackoff(attempts))); err != nil {
+		fmt.Println(err.Err)
+	}
+}
+
+func (usecase *orderUseCase) enroll(tx *gorm.DB, orderID int64) *response.Error {
+	order, err := usecase.repository.WithTx(tx).FindOneById(int(orderID))
+
+	if err != nil {
+		return err
+	}
+
+	if order.UserID == nil {
+		return nil
+	}
+
+	productIDs := make([]int64, 0, len(order.OrderDetails))
+
+	for _, orderDetail := range order.OrderDetails {
+		if orderDetail.ProductID != nil {
+			productIDs = append(productIDs, *orderDetail.ProductID)
+		}
+	}
+
+	return usecase.classRoomUseCase.WithTx(tx).Enroll(*order.UserID, productIDs)
+}
+
+func paymentDuration() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("ORDER_PAYMENT_DURATION"))
+
+	if err != nil || duration <= 0 {
+		return defaultPaymentDuration
+	}
+
+	return duration
+}
+
+// expiryBackoff doubles the delay after every failed expiry, capped at
+// maxExpiryBackoff
+func expiryBackoff(attempts int) time.Duration {
+	delay := expiryInterval
+
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+
+		if delay >= maxExpiryBackoff {
+			return maxExpiryBackoff
+		}
+	}
+
+	return delay
+}
+
+// pendingTTL is how long an order may wait for payment. By default it runs
+// an hour past the invoice, so the provider expires the invoice and its
+// callback usually settles the order first.
+func pendingTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("ORDER_PENDING_TTL"))
+
+	if err != nil || ttl <= 0 {
+		return paymentDuration() + expiryGrace
+	}
+
+	return ttl
+}
+
+func NewOrderUseCase(
+	db *gorm.DB,
+	repository repository.OrderRepository,
+	cartUseCase cartUseCase.CartUseCase,
+	discountUseCase discountUseCase.DiscountUseCase,
+	classRoomUseCase classRoomUseCase.ClassRoomUseCase,
+	orderNotificationUseCase orderNotificationUseCase.OrderNotificationUseCase,
+	payment payment.Payment,
+) OrderUseCase {
+	return &orderUseCase{
+		db,
+		repository,
+		cartUseCase,
+		discountUseCase,
+		classRoomUseCase,
+		orderNotificationUseCase,
+		payment,
+	}
+}
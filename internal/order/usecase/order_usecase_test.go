@@ -0,0 +1,129 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net/http"
+	"testing"
+
+	entity "e-course-management/internal/order/entity"
+	repository "e-course-management/internal/order/repository"
+	"e-course-management/pkg/payment"
+	"e-course-management/pkg/response"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestPaymentCallbackRejectsUnderpayment(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"zero amount", `{"id": "fake-order-1", "status": "paid", "amount": 0}`},
+		{"missing amount", `{"id": "fake-order-1", "status": "paid"}`},
+		{"partial amount", `{"id": "fake-order-1", "status": "paid", "amount": 99999}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repository := &stubOrderRepository{
+				order: entity.Order{ID: 1, TotalPrice: 100000, Status: entity.StatusPending},
+			}
+
+			usecase := &orderUseCase{
+				db:         openTxOnlyDB(t),
+				repository: repository,
+				payment:    payment.NewFakePayment("http://localhost", "secret"),
+			}
+
+			header := http.Header{}
+			header.Set("X-Callback-Token", "secret")
+
+			err := usecase.PaymentCallback(header, []byte(test.body))
+
+			if err == nil || err.Code != 400 {
+				t.Fatalf("got %v, want a 400 error", err)
+			}
+
+			if repository.statusUpdated {
+				t.Fatal("order status changed for an underpaid invoice")
+			}
+		})
+	}
+}
+
+// stubOrderRepository serves a single order, calling any other method panics
+type stubOrderRepository struct {
+	repository.OrderRepository
+	order         entity.Order
+	statusUpdated bool
+}
+
+func (repository *stubOrderRepository) FindOneByExternalId(externalID string) (*entity.Order, *response.Error) {
+	order := repository.order
+
+	return &order, nil
+}
+
+func (repository *stubOrderRepository) UpdateStatus(id int64, from entity.Status, to entity.Status) (bool, *response.Error) {
+	repository.statusUpdated = true
+
+	return true, nil
+}
+
+func (repository *stubOrderRepository) WithTx(tx *gorm.DB) repository.OrderRepository {
+	return repository
+}
+
+// openTxOnlyDB opens a database that can only begin and end transactions,
+// enough for use cases whose repositories are stubbed
+func openTxOnlyDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sql.OpenDB(txOnlyConnector{}),
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+
+	return db
+}
+
+type txOnlyConnector struct{}
+
+func (txOnlyConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return txOnlyConn{}, nil
+}
+
+func (connector txOnlyConnector) Driver() driver.Driver {
+	return nil
+}
+
+type txOnlyConn struct{}
+
+func (txOnlyConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("queries are not supported")
+}
+
+func (txOnlyConn) Close() error {
+	return nil
+}
+
+func (conn txOnlyConn) Begin() (driver.Tx, error) {
+	return conn, nil
+}
+
+func (txOnlyConn) Commit() error {
+	return nil
+}
+
+func (txOnlyConn) Rollback() error {
+	return nil
+}
@@ -0,0 +1,21 @@
+package order_detail
+
+import (
+	product "e-course-management/internal/product/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OrderDetail struct {
+	ID          int64            `json:"id"`
+	OrderID     *int64           `json:"order_id"`
+	Product     *product.Product `json:"product" gorm:"foreignKey:ProductID;references:ID"`
+	ProductID   *int64           `json:"product_id"`
+	Price       int64            `json:"price"`
+	CreatedByID *int64           `json:"created_by" gorm:"column:created_by"`
+	UpdatedByID *int64           `json:"updated_by" gorm:"column:updated_by"`
+	CreatedAt   *time.Time       `json:"created_at"`
+	UpdatedAt   *time.Time       `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt   `json:"deleted_at"`
+}
@@ -0,0 +1,29 @@
+package order_notification
+
+type OrderEmailLine struct {
+	TITLE string
+	PRICE int64
+}
+
+type OrderReceiptEmail struct {
+	NAME        string
+	ORDER_ID    int64
+	LINES       []OrderEmailLine
+	PRICE       int64
+	DISCOUNT    int64
+	TOTAL_PRICE int64
+	PAID_AT     string
+}
+
+type PaymentReminderEmail struct {
+	NAME          string
+	ORDER_ID      int64
+	LINES         []OrderEmailLine
+	TOTAL_PRICE   int64
+	CHECKOUT_LINK string
+}
+
+type AccessGrantedEmail struct {
+	NAME        string
+	CLASS_ROOMS []string
+}
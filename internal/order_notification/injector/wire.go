@@ -0,0 +1,27 @@
+//go:build wireinject
+// +build wireinject
+
+package order_notification
+
+import (
+	emailOutboxRepository "e-course-management/internal/email_outbox/repository"
+	emailSuppressionRepository "e-course-management/internal/email_suppression/repository"
+	repository "e-course-management/internal/order_notification/repository"
+	usecase "e-course-management/internal/order_notification/usecase"
+	mail "e-course-management/pkg/mail/sendgrid"
+
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+func InitializedWorker(db *gorm.DB) usecase.OrderNotificationUseCase {
+	wire.Build(
+		usecase.NewOrderNotificationUseCase,
+		repository.NewOrderNotificationRepository,
+		mail.NewMailUseCase,
+		emailOutboxRepository.NewEmailOutboxRepository,
+		emailSuppressionRepository.NewEmailSuppressionRepository,
+	)
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package order_notification
+
+import (
+	"time"
+
+	classRoomEntity "e-course-management/internal/class_room/entity"
+	orderEntity "e-course-management/internal/order/entity"
+	"e-course-management/pkg/response"
+
+	"gorm.io/gorm"
+)
+
+type OrderNotificationRepository interface {
+	FindOrderById(id int64) (*orderEntity.Order, *response.Error)
+	FindOrdersAwaitingReminder(createdBefore time.Time, limit int) []orderEntity.Order
+	FindClassRooms(userID int64, productIDs []int64) []classRoomEntity.ClassRoom
+	MarkReminderSent(id int64) (bool, *response.Error)
+	WithTx(tx *gorm.DB) OrderNotificationRepository
+}
+
+type orderNotificationRepository struct {
+	db *gorm.DB
+}
+
+// FindClassRooms implements OrderNotificationRepository.
+func (repository *orderNotificationRepository) FindClassRooms(userID int64, productIDs []int64) []classRoomEntity.ClassRoom {
+	var classRooms []classRoomEntity.ClassRoom
+
+	repository.db.Preload("Product").
+		Where("user_id = ? AND product_id IN ?", userID, productIDs).
+		Find(&classRooms)
+
+	return classRooms
+}
+
+// FindOrderById implements OrderNotificationRepository.
+func (repository *orderNotificationRepository) FindOrderById(id int64) (*orderEntity.Order, *response.Error) {
+	var order orderEntity.Order
+
+	if err := repository.db.Preload("User").Preload("OrderDetails.Product").First(&order, id).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &order, nil
+}
+
+// FindOrdersAwaitingReminder implements OrderNotificationRepository.
+func (repository *orderNotificationRepository) FindOrdersAwaitingReminder(createdBefore time.Time, limit int) []orderEntity.Order {
+	var orders []orderEntity.Order
+
+	repository.db.
+		Where("status = ? AND payment_reminder_sent_at IS NULL AND created_at <= ?", orderEntity.StatusPending, createdBefore).
+		Order("created_at").
+		Limit(limit).
+		Find(&orders)
+
+	return orders
+}
+
+// MarkReminderSent implements OrderNotificationRepository.
+// It only succeeds for the first caller, so a reminder is never sent twice.
+func (repository *orderNotificationRepository) MarkReminderSent(id int64) (bool, *response.Error) {
+	result := repository.db.Model(&orderEntity.Order{}).
+		Where("id = ? AND payment_reminder_sent_at IS NULL", id).
+		Update("payment_reminder_sent_at", time.Now())
+
+	if result.Error != nil {
+		return false, &response.Error{
+			Code: 500,
+			Err:  result.Error,
+		}
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// WithTx implements OrderNotificationRepository.
+func (repository *orderNotificationRepository) WithTx(tx *gorm.DB) OrderNotificationRepository {
+	return &orderNotificationRepository{tx}
+}
+
+func NewOrderNotificationRepository(db *gorm.DB) OrderNotificationRepository {
+	return &orderNotificationRepository{db}
+}
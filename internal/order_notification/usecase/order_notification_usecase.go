@@ -0,0 +1,204 @@
+package order_notification
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	orderEntity "e-course-management/internal/order/entity"
+	dto "e-course-management/internal/order_notification/dto"
+	repository "e-course-management/internal/order_notification/repository"
+	mail "e-course-management/pkg/mail/sendgrid"
+	"e-course-management/pkg/response"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultReminderDelay = 24 * time.Hour
+	reminderBatchSize    = 50
+	reminderInterval     = 5 * time.Minute
+)
+
+// OrderNotificationUseCase turns order status changes into emails. Callers that
+// change an order's status inside a transaction should call
+// WithTx(tx).OrderStatusChanged so the emails are queued atomically with it.
+type OrderNotificationUseCase interface {
+	OrderStatusChanged(orderID int64, status orderEntity.Status) *response.Error
+	SendPaymentReminders() int
+	Run()
+	WithTx(tx *gorm.DB) OrderNotificationUseCase
+}
+
+type orderNotificationUseCase struct {
+	db         *gorm.DB
+	repository repository.OrderNotificationRepository
+	mail       mail.Mail
+}
+
+// OrderStatusChanged implements OrderNotificationUseCase.
+func (usecase *orderNotificationUseCase) OrderStatusChanged(orderID int64, status orderEntity.Status) *response.Error {
+	if status != orderEntity.StatusPaid {
+		return nil
+	}
+
+	order, err := usecase.repository.FindOrderById(orderID)
+
+	if err != nil {
+		return err
+	}
+
+	if order.User == nil {
+		return nil
+	}
+
+	lines, productIDs := orderLines(order)
+
+	receipt := dto.OrderReceiptEmail{
+		NAME:        order.User.Name,
+		ORDER_ID:    order.ID,
+		LINES:       lines,
+		PRICE:       order.Price,
+		DISCOUNT:    order.Price - order.TotalPrice,
+		TOTAL_PRICE: order.TotalPrice,
+		PAID_AT:     time.Now().Format("02 January 2006 15:04"),
+	}
+
+	if err := usecase.mail.SendOrderReceipt(order.User.Email, order.User.Locale, receipt); err != nil {
+		return err
+	}
+
+	classRooms := usecase.repository.FindClassRooms(order.User.ID, productIDs)
+
+	if len(classRooms) == 0 {
+		return nil
+	}
+
+	accessGranted := dto.AccessGrantedEmail{
+		NAME: order.User.Name,
+	}
+
+	for _, classRoom := range classRooms {
+		if classRoom.Product != nil {
+			accessGranted.CLASS_ROOMS = append(accessGranted.CLASS_ROOMS, classRoom.Product.Title)
+		}
+	}
+
+	return usecase.mail.SendAccessGranted(order.User.Email, order.User.Locale, accessGranted)
+}
+
+// SendPaymentReminders implements OrderNotificationUseCase.
+// Orders still pending after ORDER_PAYMENT_REMINDER_DELAY get a single reminder.
+func (usecase *orderNotificationUseCase) SendPaymentReminders() int {
+	orders := usecase.repository.FindOrdersAwaitingReminder(time.Now().Add(-reminderDelay()), reminderBatchSize)
+
+	sent := 0
+
+	for _, pending := range orders {
+		errTx := usecase.db.Transaction(func(tx *gorm.DB) error {
+			txRepository := usecase.repository.WithTx(tx)
+
+			claimed, err := txRepository.MarkReminderSent(pending.ID)
+
+			if err != nil {
+				return err
+			}
+
+			if !claimed {
+				return nil
+			}
+
+			order, err := txRepository.FindOrderById(pending.ID)
+
+			if err != nil {
+				return err
+			}
+
+			if order.User == nil {
+				return nil
+			}
+
+			lines, _ := orderLines(order)
+
+			reminder := dto.PaymentReminderEmail{
+				NAME:        order.User.Name,
+				ORDER_ID:    order.ID,
+				LINES:       lines,
+				TOTAL_PRICE: order.TotalPrice,
+			}
+
+			if order.CheckoutLink != nil {
+				reminder.CHECKOUT_LINK = *order.CheckoutLink
+			}
+
+			if err := usecase.mail.WithTx(tx).SendPaymentReminder(order.User.Email, order.User.Locale, reminder); err != nil {
+				return err
+			}
+
+			sent++
+
+			return nil
+		})
+
+		if errTx != nil {
+			fmt.Println(errTx)
+		}
+	}
+
+	return sent
+}
+
+// Run implements OrderNotificationUseCase.
+func (usecase *orderNotificationUseCase) Run() {
+	ticker := time.NewTicker(reminderInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		usecase.SendPaymentReminders()
+	}
+}
+
+// WithTx implements OrderNotificationUseCase.
+func (usecase *orderNotificationUseCase) WithTx(tx *gorm.DB) OrderNotificationUseCase {
+	return &orderNotificationUseCase{
+		db:         tx,
+		repository: usecase.repository.WithTx(tx),
+		mail:       usecase.mail.WithTx(tx),
+	}
+}
+
+func orderLines(order *orderEntity.Order) ([]dto.OrderEmailLine, []int64) {
+	var lines []dto.OrderEmailLine
+	var productIDs []int64
+
+	for _, orderDetail := range order.OrderDetails {
+		line := dto.OrderEmailLine{PRICE: orderDetail.Price}
+
+		if orderDetail.Product != nil {
+			line.TITLE = orderDetail.Product.Title
+			productIDs = append(productIDs, orderDetail.Product.ID)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, productIDs
+}
+
+func reminderDelay() time.Duration {
+	delay, err := time.ParseDuration(os.Getenv("ORDER_PAYMENT_REMINDER_DELAY"))
+
+	if err != nil || delay <= 0 {
+		return defaultReminderDelay
+	}
+
+	return delay
+}
+
+func NewOrderNotificationUseCase(
+	db *gorm.DB,
+	repository repository.OrderNotificationRepository,
+	mail mail.Mail,
+) OrderNotificationUseCase {
+	return &orderNotificationUseCase{db, repository, mail}
+}
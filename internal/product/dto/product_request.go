@@ -0,0 +1,39 @@
+package product
+
+import "mime/multipart"
+
+type ProductRequestBody struct {
+	ProductCategoryID int64                 `form:"product_category_id" binding:"required"`
+	Title             string                `form:"title" binding:"required"`
+	Image             *multipart.FileHeader `form:"image"`
+	// Video is the key returned by POST /api/v1/media/videos
+	Video         *string `form:"video"`
+	Description   *string `form:"description"`
+	IsHighlighted bool    `form:"is_highlighted"`
+	Price         int64   `form:"price" binding:"min=0"`
+	CreatedBy     *int64  `form:"-"`
+	UpdatedBy     *int64  `form:"-"`
+}
+
+// ProductFilterRequest holds the catalog query string
+type ProductFilterRequest struct {
+	Offset            int    `form:"offset"`
+	Limit             int    `form:"limit"`
+	ProductCategoryID *int64 `form:"product_category_id"`
+	MinPrice          *int64 `form:"min_price"`
+	MaxPrice          *int64 `form:"max_price"`
+	IsHighlighted     *bool  `form:"is_highlighted"`
+	Sort              string `form:"sort" binding:"omitempty,oneof=newest price_asc price_desc rating"`
+}
+
+type ProductSearchRequest struct {
+	Query             string `form:"q" binding:"required"`
+	ProductCategoryID *int64 `form:"product_category_id"`
+	Offset            int    `form:"offset"`
+	Limit             int    `form:"limit"`
+}
+
+type ProductAutocompleteRequest struct {
+	Query string `form:"q" binding:"required"`
+	Limit int    `form:"limit"`
+}
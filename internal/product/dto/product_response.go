@@ -0,0 +1,41 @@
+package product
+
+import entity "e-course-management/internal/product/entity"
+
+type ProductListResponse struct {
+	Products []entity.Product `json:"products"`
+	Total    int64            `json:"total"`
+	Offset   int              `json:"offset"`
+	Limit    int              `json:"limit"`
+}
+
+type ProductHighlight struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type ProductSearchResult struct {
+	Product   entity.Product   `json:"product"`
+	Relevance float64          `json:"relevance"`
+	Highlight ProductHighlight `json:"highlight"`
+}
+
+type ProductCategoryFacet struct {
+	ProductCategoryID *int64 `json:"product_category_id"`
+	Name              string `json:"name"`
+	Count             int64  `json:"count"`
+}
+
+type ProductSearchResponse struct {
+	Results []ProductSearchResult  `json:"results"`
+	Facets  []ProductCategoryFacet `json:"facets"`
+	Total   int64                  `json:"total"`
+	Offset  int                    `json:"offset"`
+	Limit   int                    `json:"limit"`
+}
+
+type ProductSuggestion struct {
+	ID        int64  `json:"id"`
+	Title     string `json:"title"`
+	Highlight string `json:"highlight"`
+}
@@ -0,0 +1,35 @@
+package product
+
+import (
+	admin "e-course-management/internal/admin/entity"
+	productCategory "e-course-management/internal/product_category/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AverageRating and ReviewCount are maintained by the review module whenever
+// a review changes, so they are read only here. Video is the key of a private
+// object and is never serialized, buyers get a signed URL from the media
+// module instead.
+type Product struct {
+	ID                int64                            `json:"id"`
+	ProductCategory   *productCategory.ProductCategory `json:"product_category,omitempty" gorm:"foreignKey:ProductCategoryID;references:ID"`
+	ProductCategoryID *int64                           `json:"product_category_id"`
+	Title             string                           `json:"title"`
+	Image             *string                          `json:"image"`
+	ImageThumbnail    *string                          `json:"image_thumbnail"`
+	Video             *string                          `json:"-"`
+	Description       *string                          `json:"description"`
+	IsHighlighted     bool                             `json:"is_highlighted"`
+	Price             int64                            `json:"price"`
+	AverageRating     float64                          `json:"average_rating" gorm:"->"`
+	ReviewCount       int                              `json:"review_count" gorm:"->"`
+	CreatedByID       *int64                           `json:"created_by" gorm:"column:created_by"`
+	CreatedBy         *admin.Admin                     `json:"-" gorm:"foreignKey:CreatedByID;references:ID"`
+	UpdatedByID       *int64                           `json:"updated_by" gorm:"column:updated_by"`
+	UpdatedBy         *admin.Admin                     `json:"-" gorm:"foreignKey:UpdatedByID;references:ID"`
+	CreatedAt         *time.Time                       `json:"created_at"`
+	UpdatedAt         *time.Time                       `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt                   `json:"deleted_at"`
+}
@@ -0,0 +1,33 @@
+//go:build wireinject
+// +build wireinject
+
+package product
+
+import (
+	mediaRepository "e-course-management/internal/media/repository"
+	mediaUseCase "e-course-management/internal/media/usecase"
+	handler "e-course-management/internal/product/delivery/http"
+	repository "e-course-management/internal/product/repository"
+	usecase "e-course-management/internal/product/usecase"
+	productCategoryRepository "e-course-management/internal/product_category/repository"
+	productCategoryUseCase "e-course-management/internal/product_category/usecase"
+	"e-course-management/pkg/storage"
+
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+func InitializedService(db *gorm.DB) *handler.ProductHandler {
+	wire.Build(
+		handler.NewProductHandler,
+		usecase.NewProductUseCase,
+		repository.NewProductRepository,
+		productCategoryUseCase.NewProductCategoryUseCase,
+		productCategoryRepository.NewProductCategoryRepository,
+		mediaUseCase.NewMediaUseCase,
+		mediaRepository.NewMediaRepository,
+		storage.NewStorage,
+	)
+
+	return &handler.ProductHandler{}
+}
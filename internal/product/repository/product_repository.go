@@ -0,0 +1,219 @@
+package product
+
+import (
+	"errors"
+	"sort"
+
+	dto "e-course-management/internal/product/dto"
+	entity "e-course-management/internal/product/entity"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+type ProductRepository interface {
+	FindAll(filter dto.ProductFilterRequest) ([]entity.Product, int64)
+	FindOneById(id int) (*entity.Product, *response.Error)
+	Create(entity entity.Product) (*entity.Product, *response.Error)
+	Update(entity entity.Product) (*entity.Product, *response.Error)
+	Delete(entity entity.Product) *response.Error
+	Search(booleanQuery string, productCategoryID *int64, offset int, limit int) ([]entity.Product, map[int64]float64, int64)
+	SearchFacets(booleanQuery string) []dto.ProductCategoryFacet
+	FindTitlesMatching(booleanQuery string, limit int) []entity.Product
+}
+
+type productRepository struct {
+	db *gorm.DB
+}
+
+// Create implements ProductRepository.
+func (repository *productRepository) Create(entity entity.Product) (*entity.Product, *response.Error) {
+	if err := repository.db.Create(&entity).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &entity, nil
+}
+
+// Delete implements ProductRepository.
+func (repository *productRepository) Delete(entity entity.Product) *response.Error {
+	if err := utils.SoftDelete(repository.db, &entity, entity.UpdatedByID); err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// FindAll implements ProductRepository.
+// It returns one page of products matching the filter and the total match count.
+func (repository *productRepository) FindAll(filter dto.ProductFilterRequest) ([]entity.Product, int64) {
+	var products []entity.Product
+	var total int64
+
+	query := repository.db.Model(&entity.Product{})
+
+	if filter.ProductCategoryID != nil {
+		query = query.Where("product_category_id = ?", *filter.ProductCategoryID)
+	}
+
+	if filter.MinPrice != nil {
+		query = query.Where("price >= ?", *filter.MinPrice)
+	}
+
+	if filter.MaxPrice != nil {
+		query = query.Where("price <= ?", *filter.MaxPrice)
+	}
+
+	if filter.IsHighlighted != nil {
+		query = query.Where("is_highlighted = ?", *filter.IsHighlighted)
+	}
+
+	query = query.Session(&gorm.Session{})
+
+	query.Count(&total)
+
+	switch filter.Sort {
+	case "price_asc":
+		query = query.Order("price ASC").Order("id DESC")
+	case "price_desc":
+		query = query.Order("price DESC").Order("id DESC")
+	case "rating":
+		query = query.Order("average_rating DESC").Order("review_count DESC").Order("id DESC")
+	default:
+		query = query.Order("created_at DESC").Order("id DESC")
+	}
+
+	query.Preload("ProductCategory").
+		Scopes(utils.Paginate(filter.Offset, filter.Limit)).
+		Find(&products)
+
+	return products, total
+}
+
+// FindOneById implements ProductRepository.
+func (repository *productRepository) FindOneById(id int) (*entity.Product, *response.Error) {
+	var product entity.Product
+
+	if err := repository.db.Preload("ProductCategory").First(&product, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("product not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &product, nil
+}
+
+// Search implements ProductRepository.
+// Matches are ranked by relevance, with title matches counting double. The
+// relevance of each returned product is keyed by its id.
+func (repository *productRepository) Search(booleanQuery string, productCategoryID *int64, offset int, limit int) ([]entity.Product, map[int64]float64, int64) {
+	var matches []struct {
+		ID        int64
+		Relevance float64
+	}
+	var total int64
+
+	query := repository.db.Model(&entity.Product{}).
+		Where("MATCH(title, description) AGAINST(? IN BOOLEAN MODE)", booleanQuery)
+
+	if productCategoryID != nil {
+		query = query.Where("product_category_id = ?", *productCategoryID)
+	}
+
+	query = query.Session(&gorm.Session{})
+
+	query.Count(&total)
+
+	query.Select(
+		"id, MATCH(title) AGAINST(? IN BOOLEAN MODE) * 2 + MATCH(title, description) AGAINST(? IN BOOLEAN MODE) AS relevance",
+		booleanQuery,
+		booleanQuery,
+	).
+		Order("relevance DESC").
+		Order("id DESC").
+		Scopes(utils.Paginate(offset, limit)).
+		Scan(&matches)
+
+	relevance := map[int64]float64{}
+	ids := make([]int64, len(matches))
+
+	for i, match := range matches {
+		ids[i] = match.ID
+		relevance[match.ID] = match.Relevance
+	}
+
+	if len(ids) == 0 {
+		return []entity.Product{}, relevance, total
+	}
+
+	var products []entity.Product
+
+	repository.db.Preload("ProductCategory").Where("id IN ?", ids).Find(&products)
+
+	sort.SliceStable(products, func(i, j int) bool {
+		return relevance[products[i].ID] > relevance[products[j].ID]
+	})
+
+	return products, relevance, total
+}
+
+// SearchFacets implements ProductRepository.
+func (repository *productRepository) SearchFacets(booleanQuery string) []dto.ProductCategoryFacet {
+	var facets []dto.ProductCategoryFacet
+
+	repository.db.Model(&entity.Product{}).
+		Select("products.product_category_id, COALESCE(product_categories.name, '') AS name, COUNT(*) AS count").
+		Joins("LEFT JOIN product_categories ON product_categories.id = products.product_category_id AND product_categories.deleted_at IS NULL").
+		Where("MATCH(products.title, products.description) AGAINST(? IN BOOLEAN MODE)", booleanQuery).
+		Group("products.product_category_id, product_categories.name").
+		Order("count DESC").
+		Scan(&facets)
+
+	return facets
+}
+
+// FindTitlesMatching implements ProductRepository.
+func (repository *productRepository) FindTitlesMatching(booleanQuery string, limit int) []entity.Product {
+	var products []entity.Product
+
+	repository.db.Select("id, title").
+		Where("MATCH(title) AGAINST(? IN BOOLEAN MODE)", booleanQuery).
+		Order("id DESC").
+		Limit(limit).
+		Find(&products)
+
+	return products
+}
+
+// Update implements ProductRepository.
+func (repository *productRepository) Update(entity entity.Product) (*entity.Product, *response.Error) {
+	entity.ProductCategory = nil
+
+	if err := repository.db.Save(&entity).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &entity, nil
+}
+
+func NewProductRepository(db *gorm.DB) ProductRepository {
+	return &productRepository{db}
+}
@@ -0,0 +1,308 @@
+package product
+
+import (
+	"errors"
+
+	mediaUseCase "e-course-management/internal/media/usecase"
+	dto "e-course-management/internal/product/dto"
+	entity "e-course-management/internal/product/entity"
+	repository "e-course-management/internal/product/repository"
+	productCategoryUseCase "e-course-management/internal/product_category/usecase"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+)
+
+type ProductUseCase interface {
+	FindAll(filter dto.ProductFilterRequest) dto.ProductListResponse
+	FindOneById(id int) (*entity.Product, *response.Error)
+	Create(dto dto.ProductRequestBody) (*entity.Product, *response.Error)
+	Update(id int, dto dto.ProductRequestBody) (*entity.Product, *response.Error)
+	Delete(id int, deletedBy int64) *response.Error
+	Search(request dto.ProductSearchRequest) (*dto.ProductSearchResponse, *response.Error)
+	Autocomplete(request dto.ProductAutocompleteRequest) []dto.ProductSuggestion
+}
+
+type productUseCase struct {
+	repository             repository.ProductRepository
+	productCategoryUseCase productCategoryUseCase.ProductCategoryUseCase
+	mediaUseCase           mediaUseCase.MediaUseCase
+}
+
+// Create implements ProductUseCase.
+func (usecase *productUseCase) Create(dto dto.ProductRequestBody) (*entity.Product, *response.Error) {
+	if _, err := usecase.productCategoryUseCase.FindOneById(int(dto.ProductCategoryID)); err != nil {
+		return nil, err
+	}
+
+	product := entity.Product{
+		ProductCategoryID: &dto.ProductCategoryID,
+		Title:             dto.Title,
+		Video:             dto.Video,
+		Description:       dto.Description,
+		IsHighlighted:     dto.IsHighlighted,
+		Price:             dto.Price,
+		CreatedByID:       dto.CreatedBy,
+	}
+
+	if dto.Image != nil {
+		image, err := usecase.mediaUseCase.UploadImage(dto.Image, "products")
+
+		if err != nil {
+			return nil, err
+		}
+
+		product.Image = &image.URL
+		product.ImageThumbnail = image.ThumbnailURL
+	}
+
+	return usecase.repository.Create(product)
+}
+
+// Delete implements ProductUseCase.
+func (usecase *productUseCase) Delete(id int, deletedBy int64) *response.Error {
+	product, err := usecase.repository.FindOneById(id)
+
+	if err != nil {
+		return err
+	}
+
+	product.UpdatedByID = &deletedBy
+
+	return usecase.repository.Delete(*product)
+}
+
+// FindAll implements ProductUseCase.
+func (usecase *productUseCase) FindAll(filter dto.ProductFilterRequest) dto.ProductListResponse {
+	products, total := usecase.repository.FindAll(filter)
+
+	return dto.ProductListResponse{
+		Products: products,
+		Total:    total,
+		Offset:   filter.Offset,
+		Limit:    filter.Limit,
+	}
+}
+
+// FindOneById implements ProductUseCase.
+func (usecase *productUseCase) FindOneById(id int) (*entity.Product, *response.Error) {
+	return usecase.repository.FindOneById(id)
+}
+
+// Search implements ProductUseCase.
+// Every word is matched as a prefix and results are ranked by relevance.
+func (usecase *productUseCase) Search(request dto.ProductSearchRequest) (*dto.ProductSearchResponse, *response.Error) {
+	terms := utils.SearchTerms(request.Query)
+
+	if len(terms) == 0 {
+		return nil, &response.Error{
+			Code: 400,
+			Err:  errors.New("search query must contain at least one word"),
+		}
+	}
+
+	booleanQuery := utils.BooleanPrefixQuery(terms, false)
+
+	products, relevance, total := usecase.repository.Search(booleanQuery, request.ProductCategoryID, request.Offset, request.Limit)
+
+	return searchResponse(products, relevance, total, usecase.repository.SearchFacets(booleanQuery), terms, request), nil
+}
+
+// Autocomplete implements ProductUseCase.
+// Titles starting with every typed word come first. When those run short,
+// titles sharing the first letters of each word are accepted if the rest is
+// within a few typos, so "javscript" still suggests "JavaScript".
+func (usecase *productUseCase) Autocomplete(request dto.ProductAutocompleteRequest) []dto.ProductSuggestion {
+	terms := utils.SearchTerms(request.Query)
+	limit := request.Limit
+
+	if limit <= 0 || limit > maxSuggestions {
+		limit = maxSuggestions
+	}
+
+	suggestions := []dto.ProductSuggestion{}
+
+	if len(terms) == 0 {
+		return suggestions
+	}
+
+	seen := map[int64]bool{}
+
+	addSuggestion := func(product entity.Product) {
+		if seen[product.ID] || len(suggestions) >= limit {
+			return
+		}
+
+		seen[product.ID] = true
+		suggestions = append(suggestions, suggestion(product, terms))
+	}
+
+	for _, product := range usecase.repository.FindTitlesMatching(utils.BooleanPrefixQuery(terms, true), limit) {
+		addSuggestion(product)
+	}
+
+	if len(suggestions) >= limit {
+		return suggestions
+	}
+
+	stems := make([]string, len(terms))
+
+	for i, term := range terms {
+		stems[i] = string([]rune(term)[:minInt(len([]rune(term)), typoStemLength)])
+	}
+
+	for _, product := range usecase.repository.FindTitlesMatching(utils.BooleanPrefixQuery(stems, false), limit*typoCandidateFactor) {
+		if fuzzyPrefixMatch(terms, product.Title) {
+			addSuggestion(product)
+		}
+	}
+
+	return suggestions
+}
+
+// Update implements ProductUseCase.
+func (usecase *productUseCase) Update(id int, dto dto.ProductRequestBody) (*entity.Product, *response.Error) {
+	product, err := usecase.repository.FindOneById(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := usecase.productCategoryUseCase.FindOneById(int(dto.ProductCategoryID)); err != nil {
+		return nil, err
+	}
+
+	product.ProductCategoryID = &dto.ProductCategoryID
+	product.Title = dto.Title
+	product.Video = dto.Video
+	product.Description = dto.Description
+	product.IsHighlighted = dto.IsHighlighted
+	product.Price = dto.Price
+	product.UpdatedByID = dto.UpdatedBy
+
+	if dto.Image != nil {
+		image, err := usecase.mediaUseCase.UploadImage(dto.Image, "products")
+
+		if err != nil {
+			return nil, err
+		}
+
+		product.Image = &image.URL
+		product.ImageThumbnail = image.ThumbnailURL
+	}
+
+	return usecase.repository.Update(*product)
+}
+
+const (
+	maxSuggestions      = 10
+	typoStemLength      = 3
+	typoCandidateFactor = 5
+)
+
+func searchResponse(
+	products []entity.Product,
+	relevance map[int64]float64,
+	total int64,
+	facets []dto.ProductCategoryFacet,
+	terms []string,
+	request dto.ProductSearchRequest,
+) *dto.ProductSearchResponse {
+	results := make([]dto.ProductSearchResult, len(products))
+
+	for i, product := range products {
+		description := ""
+
+		if product.Description != nil {
+			description = *product.Description
+		}
+
+		results[i] = dto.ProductSearchResult{
+			Product:   product,
+			Relevance: relevance[product.ID],
+			Highlight: dto.ProductHighlight{
+				Title:       utils.Highlight(product.Title, terms),
+				Description: utils.Highlight(description, terms),
+			},
+		}
+	}
+
+	return &dto.ProductSearchResponse{
+		Results: results,
+		Facets:  facets,
+		Total:   total,
+		Offset:  request.Offset,
+		Limit:   request.Limit,
+	}
+}
+
+func suggestion(product entity.Product, terms []string) dto.ProductSuggestion {
+	return dto.ProductSuggestion{
+		ID:        product.ID,
+		Title:     product.Title,
+		Highlight: utils.Highlight(product.Title, terms),
+	}
+}
+
+// fuzzyPrefixMatch reports whether every term is, give or take a few typos, the
+// beginning of some word in the title
+func fuzzyPrefixMatch(terms []string, title string) bool {
+	words := utils.SearchTerms(title)
+
+	for _, term := range terms {
+		if !fuzzyPrefixMatchAny(term, words) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func fuzzyPrefixMatchAny(term string, words []string) bool {
+	length := len([]rune(term))
+	allowed := maxTypos(length)
+
+	for _, word := range words {
+		runes := []rune(word)
+
+		// Compare against slightly shorter and longer prefixes so that missing
+		// or extra letters count as a single typo
+		for size := length - 1; size <= length+1; size++ {
+			if size <= 0 || size > len(runes) {
+				continue
+			}
+
+			if utils.EditDistance(term, string(runes[:size])) <= allowed {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func maxTypos(length int) int {
+	switch {
+	case length <= 3:
+		return 0
+	case length <= 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func NewProductUseCase(
+	repository repository.ProductRepository,
+	productCategoryUseCase productCategoryUseCase.ProductCategoryUseCase,
+	mediaUseCase mediaUseCase.MediaUseCase,
+) ProductUseCase {
+	return &productUseCase{repository, productCategoryUseCase, mediaUseCase}
+}
@@ -0,0 +1,10 @@
+package product_category
+
+import "mime/multipart"
+
+type ProductCategoryRequestBody struct {
+	Name      string                `form:"name" binding:"required"`
+	Image     *multipart.FileHeader `form:"image"`
+	CreatedBy *int64                `form:"-"`
+	UpdatedBy *int64                `form:"-"`
+}
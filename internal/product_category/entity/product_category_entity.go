@@ -0,0 +1,22 @@
+package product_category
+
+import (
+	admin "e-course-management/internal/admin/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ProductCategory struct {
+	ID             int64          `json:"id"`
+	Name           string         `json:"name"`
+	Image          string         `json:"image"`
+	ImageThumbnail *string        `json:"image_thumbnail"`
+	CreatedByID    *int64         `json:"created_by" gorm:"column:created_by"`
+	CreatedBy      *admin.Admin   `json:"-" gorm:"foreignKey:CreatedByID;references:ID"`
+	UpdatedByID    *int64         `json:"updated_by" gorm:"column:updated_by"`
+	UpdatedBy      *admin.Admin   `json:"-" gorm:"foreignKey:UpdatedByID;references:ID"`
+	CreatedAt      *time.Time     `json:"created_at"`
+	UpdatedAt      *time.Time     `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"deleted_at"`
+}
@@ -0,0 +1,29 @@
+//go:build wireinject
+// +build wireinject
+
+package product_category
+
+import (
+	mediaRepository "e-course-management/internal/media/repository"
+	mediaUseCase "e-course-management/internal/media/usecase"
+	handler "e-course-management/internal/product_category/delivery/http"
+	repository "e-course-management/internal/product_category/repository"
+	usecase "e-course-management/internal/product_category/usecase"
+	"e-course-management/pkg/storage"
+
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+func InitializedService(db *gorm.DB) *handler.ProductCategoryHandler {
+	wire.Build(
+		handler.NewProductCategoryHandler,
+		usecase.NewProductCategoryUseCase,
+		repository.NewProductCategoryRepository,
+		mediaUseCase.NewMediaUseCase,
+		mediaRepository.NewMediaRepository,
+		storage.NewStorage,
+	)
+
+	return &handler.ProductCategoryHandler{}
+}
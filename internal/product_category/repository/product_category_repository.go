@@ -0,0 +1,104 @@
+package product_category
+
+import (
+	"errors"
+
+	productEntity "e-course-management/internal/product/entity"
+	entity "e-course-management/internal/product_category/entity"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+type ProductCategoryRepository interface {
+	FindAll(offset int, limit int) []entity.ProductCategory
+	FindOneById(id int) (*entity.ProductCategory, *response.Error)
+	Create(entity entity.ProductCategory) (*entity.ProductCategory, *response.Error)
+	Update(entity entity.ProductCategory) (*entity.ProductCategory, *response.Error)
+	Delete(entity entity.ProductCategory) *response.Error
+	TotalCountProduct(id int64) int64
+}
+
+type productCategoryRepository struct {
+	db *gorm.DB
+}
+
+// Create implements ProductCategoryRepository.
+func (repository *productCategoryRepository) Create(entity entity.ProductCategory) (*entity.ProductCategory, *response.Error) {
+	if err := repository.db.Create(&entity).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &entity, nil
+}
+
+// Delete implements ProductCategoryRepository.
+func (repository *productCategoryRepository) Delete(entity entity.ProductCategory) *response.Error {
+	if err := utils.SoftDelete(repository.db, &entity, entity.UpdatedByID); err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// FindAll implements ProductCategoryRepository.
+func (repository *productCategoryRepository) FindAll(offset int, limit int) []entity.ProductCategory {
+	var productCategories []entity.ProductCategory
+
+	repository.db.Scopes(utils.Paginate(offset, limit)).Find(&productCategories)
+
+	return productCategories
+}
+
+// FindOneById implements ProductCategoryRepository.
+func (repository *productCategoryRepository) FindOneById(id int) (*entity.ProductCategory, *response.Error) {
+	var productCategory entity.ProductCategory
+
+	if err := repository.db.First(&productCategory, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("product category not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &productCategory, nil
+}
+
+// TotalCountProduct implements ProductCategoryRepository.
+func (repository *productCategoryRepository) TotalCountProduct(id int64) int64 {
+	var count int64
+
+	repository.db.Model(&productEntity.Product{}).Where("product_category_id = ?", id).Count(&count)
+
+	return count
+}
+
+// Update implements ProductCategoryRepository.
+func (repository *productCategoryRepository) Update(entity entity.ProductCategory) (*entity.ProductCategory, *response.Error) {
+	if err := repository.db.Save(&entity).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &entity, nil
+}
+
+func NewProductCategoryRepository(db *gorm.DB) ProductCategoryRepository {
+	return &productCategoryRepository{db}
+}
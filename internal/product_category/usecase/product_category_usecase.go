@@ -0,0 +1,111 @@
+package product_category
+
+import (
+	"errors"
+
+	mediaUseCase "e-course-management/internal/media/usecase"
+	dto "e-course-management/internal/product_category/dto"
+	entity "e-course-management/internal/product_category/entity"
+	repository "e-course-management/internal/product_category/repository"
+	"e-course-management/pkg/response"
+)
+
+type ProductCategoryUseCase interface {
+	FindAll(offset int, limit int) []entity.ProductCategory
+	FindOneById(id int) (*entity.ProductCategory, *response.Error)
+	Create(dto dto.ProductCategoryRequestBody) (*entity.ProductCategory, *response.Error)
+	Update(id int, dto dto.ProductCategoryRequestBody) (*entity.ProductCategory, *response.Error)
+	Delete(id int, deletedBy int64) *response.Error
+}
+
+type productCategoryUseCase struct {
+	repository   repository.ProductCategoryRepository
+	mediaUseCase mediaUseCase.MediaUseCase
+}
+
+// Create implements ProductCategoryUseCase.
+func (usecase *productCategoryUseCase) Create(dto dto.ProductCategoryRequestBody) (*entity.ProductCategory, *response.Error) {
+	if dto.Image == nil {
+		return nil, &response.Error{
+			Code: 400,
+			Err:  errors.New("image is required"),
+		}
+	}
+
+	image, err := usecase.mediaUseCase.UploadImage(dto.Image, "product_categories")
+
+	if err != nil {
+		return nil, err
+	}
+
+	productCategory := entity.ProductCategory{
+		Name:           dto.Name,
+		Image:          image.URL,
+		ImageThumbnail: image.ThumbnailURL,
+		CreatedByID:    dto.CreatedBy,
+	}
+
+	return usecase.repository.Create(productCategory)
+}
+
+// Delete implements ProductCategoryUseCase.
+func (usecase *productCategoryUseCase) Delete(id int, deletedBy int64) *response.Error {
+	productCategory, err := usecase.repository.FindOneById(id)
+
+	if err != nil {
+		return err
+	}
+
+	if usecase.repository.TotalCountProduct(productCategory.ID) > 0 {
+		return &response.Error{
+			Code: 409,
+			Err:  errors.New("product category still has products"),
+		}
+	}
+
+	productCategory.UpdatedByID = &deletedBy
+
+	return usecase.repository.Delete(*productCategory)
+}
+
+// FindAll implements ProductCategoryUseCase.
+func (usecase *productCategoryUseCase) FindAll(offset int, limit int) []entity.ProductCategory {
+	return usecase.repository.FindAll(offset, limit)
+}
+
+// FindOneById implements ProductCategoryUseCase.
+func (usecase *productCategoryUseCase) FindOneById(id int) (*entity.ProductCategory, *response.Error) {
+	return usecase.repository.FindOneById(id)
+}
+
+// Update implements ProductCategoryUseCase.
+func (usecase *productCategoryUseCase) Update(id int, dto dto.ProductCategoryRequestBody) (*entity.ProductCategory, *response.Error) {
+	productCategory, err := usecase.repository.FindOneById(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	productCategory.Name = dto.Name
+	productCategory.UpdatedByID = dto.UpdatedBy
+
+	if dto.Image != nil {
+		image, err := usecase.mediaUseCase.UploadImage(dto.Image, "product_categories")
+
+		if err != nil {
+			return nil, err
+		}
+
+		productCategory.Image = image.URL
+		productCategory.ImageThumbnail = image.ThumbnailURL
+	}
+
+	return usecase.repository.Update(*productCategory)
+}
+
+func NewProductCategoryUseCase(
+	repository repository.ProductCategoryRepository,
+	mediaUseCase mediaUseCase.MediaUseCase,
+) ProductCategoryUseCase {
+	return &productCategoryUseCase{repository, mediaUseCase}
+}
@@ -0,0 +1,43 @@
+package quiz
+
+// QuizRequestBody configures the quiz of a quiz lesson. TimeLimit is in
+// seconds; MaxAttempts, TimeLimit and QuestionCount are unlimited when empty.
+type QuizRequestBody struct {
+	PassingScore  int    `json:"passing_score" binding:"min=0,max=100"`
+	MaxAttempts   *int   `json:"max_attempts" binding:"omitempty,min=1"`
+	TimeLimit     *int   `json:"time_limit" binding:"omitempty,min=1"`
+	QuestionCount *int   `json:"question_count" binding:"omitempty,min=1"`
+	CreatedBy     *int64 `json:"-"`
+	UpdatedBy     *int64 `json:"-"`
+}
+
+// QuizQuestionRequestBody holds the choices of a choice question, or the
+// accepted answers of a short answer question
+type QuizQuestionRequestBody struct {
+	Type      string                  `json:"type" binding:"required,oneof=single_choice multiple_choice short_answer"`
+	Question  string                  `json:"question" binding:"required"`
+	Points    int                     `json:"points" binding:"required,min=1"`
+	Options   []QuizOptionRequestBody `json:"options" binding:"required,min=1,dive"`
+	CreatedBy *int64                  `json:"-"`
+	UpdatedBy *int64                  `json:"-"`
+}
+
+// QuizOptionRequestBody keeps an existing option when ID is sent back on an
+// update, options without one are added
+type QuizOptionRequestBody struct {
+	ID        *int64 `json:"id"`
+	Content   string `json:"content" binding:"required"`
+	IsCorrect bool   `json:"is_correct"`
+}
+
+type QuizSubmitRequestBody struct {
+	Answers []QuizAnswerRequestBody `json:"answers" binding:"dive"`
+}
+
+// QuizAnswerRequestBody answers a choice question with OptionIDs and a short
+// answer question with Answer
+type QuizAnswerRequestBody struct {
+	QuestionID int64   `json:"question_id" binding:"required"`
+	OptionIDs  []int64 `json:"option_ids"`
+	Answer     *string `json:"answer"`
+}
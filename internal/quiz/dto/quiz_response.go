@@ -0,0 +1,62 @@
+package quiz
+
+import (
+	entity "e-course-management/internal/quiz/entity"
+	"time"
+)
+
+// QuizResponse is the quiz of a lesson as seen by an enrolled user.
+// AttemptsLeft is empty when the quiz has no attempt limit.
+type QuizResponse struct {
+	ID                  int64                `json:"id"`
+	LessonID            int64                `json:"lesson_id"`
+	PassingScore        int                  `json:"passing_score"`
+	MaxAttempts         *int                 `json:"max_attempts"`
+	TimeLimit           *int                 `json:"time_limit"`
+	QuestionCount       int                  `json:"question_count"`
+	AttemptsUsed        int                  `json:"attempts_used"`
+	AttemptsLeft        *int                 `json:"attempts_left"`
+	BestPercentage      int                  `json:"best_percentage"`
+	IsPassed            bool                 `json:"is_passed"`
+	InProgressAttemptID *int64               `json:"in_progress_attempt_id"`
+	Attempts            []entity.QuizAttempt `json:"attempts"`
+}
+
+// QuizAttemptResponse never exposes the correct answers. The score and the
+// result of each question are filled once the attempt is submitted.
+type QuizAttemptResponse struct {
+	ID           int64                 `json:"id"`
+	QuizID       int64                 `json:"quiz_id"`
+	LessonID     int64                 `json:"lesson_id"`
+	Status       string                `json:"status"`
+	StartedAt    time.Time             `json:"started_at"`
+	ExpiresAt    *time.Time            `json:"expires_at"`
+	SubmittedAt  *time.Time            `json:"submitted_at"`
+	Score        int                   `json:"score"`
+	MaxScore     int                   `json:"max_score"`
+	Percentage   int                   `json:"percentage"`
+	PassingScore int                   `json:"passing_score"`
+	IsPassed     bool                  `json:"is_passed"`
+	Questions    []QuizAttemptQuestion `json:"questions"`
+}
+
+type QuizAttemptQuestion struct {
+	ID       int64                      `json:"id"`
+	Type     string                     `json:"type"`
+	Question string                     `json:"question"`
+	Points   int                        `json:"points"`
+	Options  []QuizAttemptOption        `json:"options"`
+	Result   *QuizAttemptQuestionResult `json:"result,omitempty"`
+}
+
+type QuizAttemptOption struct {
+	ID      int64  `json:"id"`
+	Content string `json:"content"`
+}
+
+type QuizAttemptQuestionResult struct {
+	OptionIDs []int64 `json:"option_ids"`
+	Answer    *string `json:"answer"`
+	IsCorrect bool    `json:"is_correct"`
+	Points    int     `json:"points"`
+}
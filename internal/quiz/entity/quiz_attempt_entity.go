@@ -0,0 +1,47 @@
+package quiz
+
+import (
+	user "e-course-management/internal/user/entity"
+	"time"
+)
+
+const (
+	AttemptStatusInProgress = "in_progress"
+	AttemptStatusSubmitted  = "submitted"
+	AttemptStatusExpired    = "expired"
+)
+
+// QuizAttempt stores the questions drawn for the attempt in the order they
+// are shown. Score and MaxScore are points, Percentage is compared with the
+// passing score of the quiz.
+type QuizAttempt struct {
+	ID          int64               `json:"id"`
+	QuizID      int64               `json:"quiz_id"`
+	UserID      int64               `json:"user_id"`
+	User        *user.User          `json:"user,omitempty" gorm:"foreignKey:UserID;references:ID"`
+	ClassRoomID int64               `json:"class_room_id"`
+	Status      string              `json:"status"`
+	QuestionIDs []int64             `json:"question_ids" gorm:"serializer:json"`
+	StartedAt   time.Time           `json:"started_at"`
+	ExpiresAt   *time.Time          `json:"expires_at"`
+	SubmittedAt *time.Time          `json:"submitted_at"`
+	Score       int                 `json:"score"`
+	MaxScore    int                 `json:"max_score"`
+	Percentage  int                 `json:"percentage"`
+	IsPassed    bool                `json:"is_passed"`
+	Answers     []QuizAttemptAnswer `json:"answers,omitempty" gorm:"foreignKey:QuizAttemptID;references:ID"`
+	CreatedAt   *time.Time          `json:"created_at"`
+	UpdatedAt   *time.Time          `json:"updated_at"`
+}
+
+type QuizAttemptAnswer struct {
+	ID             int64      `json:"id"`
+	QuizAttemptID  int64      `json:"quiz_attempt_id"`
+	QuizQuestionID int64      `json:"quiz_question_id"`
+	OptionIDs      []int64    `json:"option_ids" gorm:"serializer:json"`
+	Answer         *string    `json:"answer"`
+	IsCorrect      bool       `json:"is_correct"`
+	Points         int        `json:"points"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+}
@@ -0,0 +1,28 @@
+package quiz
+
+import (
+	admin "e-course-management/internal/admin/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Quiz settings of a quiz lesson. PassingScore is a percentage, TimeLimit is
+// in seconds and QuestionCount draws that many questions from the bank for
+// every attempt; nil means no limit.
+type Quiz struct {
+	ID            int64          `json:"id"`
+	LessonID      int64          `json:"lesson_id"`
+	PassingScore  int            `json:"passing_score"`
+	MaxAttempts   *int           `json:"max_attempts"`
+	TimeLimit     *int           `json:"time_limit"`
+	QuestionCount *int           `json:"question_count"`
+	Questions     []QuizQuestion `json:"questions,omitempty" gorm:"foreignKey:QuizID;references:ID"`
+	CreatedByID   *int64         `json:"created_by" gorm:"column:created_by"`
+	CreatedBy     *admin.Admin   `json:"-" gorm:"foreignKey:CreatedByID;references:ID"`
+	UpdatedByID   *int64         `json:"updated_by" gorm:"column:updated_by"`
+	UpdatedBy     *admin.Admin   `json:"-" gorm:"foreignKey:UpdatedByID;references:ID"`
+	CreatedAt     *time.Time     `json:"created_at"`
+	UpdatedAt     *time.Time     `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"deleted_at"`
+}
@@ -0,0 +1,44 @@
+package quiz
+
+import (
+	admin "e-course-management/internal/admin/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	QuestionTypeSingleChoice   = "single_choice"
+	QuestionTypeMultipleChoice = "multiple_choice"
+	QuestionTypeShortAnswer    = "short_answer"
+)
+
+type QuizQuestion struct {
+	ID          int64                `json:"id"`
+	QuizID      int64                `json:"quiz_id"`
+	Type        string               `json:"type"`
+	Question    string               `json:"question"`
+	Points      int                  `json:"points"`
+	Position    int                  `json:"position"`
+	Options     []QuizQuestionOption `json:"options" gorm:"foreignKey:QuizQuestionID;references:ID"`
+	CreatedByID *int64               `json:"created_by" gorm:"column:created_by"`
+	CreatedBy   *admin.Admin         `json:"-" gorm:"foreignKey:CreatedByID;references:ID"`
+	UpdatedByID *int64               `json:"updated_by" gorm:"column:updated_by"`
+	UpdatedBy   *admin.Admin         `json:"-" gorm:"foreignKey:UpdatedByID;references:ID"`
+	CreatedAt   *time.Time           `json:"created_at"`
+	UpdatedAt   *time.Time           `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt       `json:"deleted_at"`
+}
+
+// QuizQuestionOption is a choice of a choice question, or an accepted answer
+// of a short answer question
+type QuizQuestionOption struct {
+	ID             int64          `json:"id"`
+	QuizQuestionID int64          `json:"quiz_question_id"`
+	Content        string         `json:"content"`
+	IsCorrect      bool           `json:"is_correct"`
+	Position       int            `json:"position"`
+	CreatedAt      *time.Time     `json:"created_at"`
+	UpdatedAt      *time.Time     `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"deleted_at"`
+}
@@ -0,0 +1,43 @@
+//go:build wireinject
+// +build wireinject
+
+package quiz
+
+import (
+	certificateRepository "e-course-management/internal/certificate/repository"
+	certificateUseCase "e-course-management/internal/certificate/usecase"
+	emailOutboxRepository "e-course-management/internal/email_outbox/repository"
+	emailSuppressionRepository "e-course-management/internal/email_suppression/repository"
+	lessonProgressRepository "e-course-management/internal/lesson_progress/repository"
+	lessonProgressUseCase "e-course-management/internal/lesson_progress/usecase"
+	mediaRepository "e-course-management/internal/media/repository"
+	mediaUseCase "e-course-management/internal/media/usecase"
+	handler "e-course-management/internal/quiz/delivery/http"
+	repository "e-course-management/internal/quiz/repository"
+	usecase "e-course-management/internal/quiz/usecase"
+	mail "e-course-management/pkg/mail/sendgrid"
+	"e-course-management/pkg/storage"
+
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+func InitializedService(db *gorm.DB) *handler.QuizHandler {
+	wire.Build(
+		handler.NewQuizHandler,
+		usecase.NewQuizUseCase,
+		repository.NewQuizRepository,
+		lessonProgressUseCase.NewLessonProgressUseCase,
+		lessonProgressRepository.NewLessonProgressRepository,
+		certificateUseCase.NewCertificateUseCase,
+		certificateRepository.NewCertificateRepository,
+		mediaUseCase.NewMediaUseCase,
+		mediaRepository.NewMediaRepository,
+		storage.NewStorage,
+		mail.NewMailUseCase,
+		emailOutboxRepository.NewEmailOutboxRepository,
+		emailSuppressionRepository.NewEmailSuppressionRepository,
+	)
+
+	return &handler.QuizHandler{}
+}
@@ -0,0 +1,452 @@
+package quiz
+
+import (
+	"errors"
+
+	classRoomEntity "e-course-management/internal/class_room/entity"
+	curriculumEntity "e-course-management/internal/curriculum/entity"
+	entity "e-course-management/internal/quiz/entity"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type QuizRepository interface {
+	FindLessonById(id int) (*curriculumEntity.Lesson, *response.Error)
+	FindClassRoom(userID int64, productID int64) (*classRoomEntity.ClassRoom, *response.Error)
+	LockClassRoom(classRoomID int64) *response.Error
+	FindOneById(id int) (*entity.Quiz, *response.Error)
+	FindOneByLessonId(lessonID int64) (*entity.Quiz, *response.Error)
+	Create(quiz entity.Quiz) (*entity.Quiz, *response.Error)
+	Update(quiz entity.Quiz) (*entity.Quiz, *response.Error)
+	FindQuestionById(id int) (*entity.QuizQuestion, *response.Error)
+	FindQuestionsByIds(ids []int64) []entity.QuizQuestion
+	CreateQuestion(question entity.QuizQuestion) (*entity.QuizQuestion, *response.Error)
+	UpdateQuestion(question entity.QuizQuestion) (*entity.QuizQuestion, *response.Error)
+	DeleteQuestion(question entity.QuizQuestion) *response.Error
+	NextQuestionPosition(quizID int64) int
+	FindAttempts(quizID int64, userID int64, offset int, limit int) []entity.QuizAttempt
+	FindAttemptsByClassRoomId(quizID int64, classRoomID int64) []entity.QuizAttempt
+	FindAttemptById(id int) (*entity.QuizAttempt, *response.Error)
+	CreateAttempt(attempt entity.QuizAttempt) (*entity.QuizAttempt, *response.Error)
+	ExpireAttempt(id int64) (bool, *response.Error)
+	SubmitAttempt(attempt entity.QuizAttempt) (bool, *response.Error)
+	WithTx(tx *gorm.DB) QuizRepository
+}
+
+type quizRepository struct {
+	db *gorm.DB
+}
+
+// Create implements QuizRepository.
+func (repository *quizRepository) Create(quiz entity.Quiz) (*entity.Quiz, *response.Error) {
+	if err := repository.db.Create(&quiz).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &quiz, nil
+}
+
+// CreateAttempt implements QuizRepository.
+func (repository *quizRepository) CreateAttempt(attempt entity.QuizAttempt) (*entity.QuizAttempt, *response.Error) {
+	if err := repository.db.Create(&attempt).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &attempt, nil
+}
+
+// CreateQuestion implements QuizRepository.
+// The options are created along with the question.
+func (repository *quizRepository) CreateQuestion(question entity.QuizQuestion) (*entity.QuizQuestion, *response.Error) {
+	if err := repository.db.Create(&question).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &question, nil
+}
+
+// DeleteQuestion implements QuizRepository.
+func (repository *quizRepository) DeleteQuestion(question entity.QuizQuestion) *response.Error {
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&question).Update("updated_by", question.UpdatedByID).Error; err != nil {
+			return err
+		}
+
+		return tx.Omit(clause.Associations).Delete(&question).Error
+	})
+
+	if err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// ExpireAttempt implements QuizRepository.
+// It reports whether the attempt was still in progress.
+func (repository *quizRepository) ExpireAttempt(id int64) (bool, *response.Error) {
+	result := repository.db.Model(&entity.QuizAttempt{}).
+		Where("id = ? AND status = ?", id, entity.AttemptStatusInProgress).
+		Update("status", entity.AttemptStatusExpired)
+
+	if result.Error != nil {
+		return false, &response.Error{
+			Code: 500,
+			Err:  result.Error,
+		}
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// FindAttemptById implements QuizRepository.
+func (repository *quizRepository) FindAttemptById(id int) (*entity.QuizAttempt, *response.Error) {
+	var attempt entity.QuizAttempt
+
+	if err := repository.db.Preload("Answers").First(&attempt, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("quiz attempt not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &attempt, nil
+}
+
+// FindAttempts implements QuizRepository.
+// Attempts of every user are listed when userID is zero.
+func (repository *quizRepository) FindAttempts(quizID int64, userID int64, offset int, limit int) []entity.QuizAttempt {
+	var attempts []entity.QuizAttempt
+
+	query := repository.db.
+		Scopes(utils.Paginate(offset, limit)).
+		Preload("User").
+		Preload("Answers").
+		Where("quiz_id = ?", quizID).
+		Order("id DESC")
+
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	query.Find(&attempts)
+
+	return attempts
+}
+
+// FindAttemptsByClassRoomId implements QuizRepository.
+func (repository *quizRepository) FindAttemptsByClassRoomId(quizID int64, classRoomID int64) []entity.QuizAttempt {
+	var attempts []entity.QuizAttempt
+
+	repository.db.
+		Where("quiz_id = ? AND class_room_id = ?", quizID, classRoomID).
+		Order("id DESC").
+		Find(&attempts)
+
+	return attempts
+}
+
+// FindClassRoom implements QuizRepository.
+func (repository *quizRepository) FindClassRoom(userID int64, productID int64) (*classRoomEntity.ClassRoom, *response.Error) {
+	var classRoom classRoomEntity.ClassRoom
+
+	err := repository.db.
+		Where("user_id = ? AND product_id = ?", userID, productID).
+		First(&classRoom).
+		Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 403,
+				Err:  errors.New("you are not enrolled in this course"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &classRoom, nil
+}
+
+// FindLessonById implements QuizRepository.
+func (repository *quizRepository) FindLessonById(id int) (*curriculumEntity.Lesson, *response.Error) {
+	var lesson curriculumEntity.Lesson
+
+	if err := repository.db.Preload("Section").First(&lesson, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("lesson not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &lesson, nil
+}
+
+// FindOneById implements QuizRepository.
+func (repository *quizRepository) FindOneById(id int) (*entity.Quiz, *response.Error) {
+	return repository.findOne(repository.db.Where("id = ?", id))
+}
+
+// FindOneByLessonId implements QuizRepository.
+func (repository *quizRepository) FindOneByLessonId(lessonID int64) (*entity.Quiz, *response.Error) {
+	return repository.findOne(repository.db.Where("lesson_id = ?", lessonID))
+}
+
+// FindQuestionById implements QuizRepository.
+func (repository *quizRepository) FindQuestionById(id int) (*entity.QuizQuestion, *response.Error) {
+	var question entity.QuizQuestion
+
+	if err := repository.db.Preload("Options", orderByPosition).First(&question, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("quiz question not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &question, nil
+}
+
+// FindQuestionsByIds implements QuizRepository.
+// Deleted questions are included so that attempts drawn before the deletion
+// can still be shown and graded.
+func (repository *quizRepository) FindQuestionsByIds(ids []int64) []entity.QuizQuestion {
+	var questions []entity.QuizQuestion
+
+	if len(ids) == 0 {
+		return questions
+	}
+
+	repository.db.
+		Unscoped().
+		Preload("Options", func(db *gorm.DB) *gorm.DB {
+			return db.Where("deleted_at IS NULL").Order("position, id")
+		}).
+		Where("id IN ?", ids).
+		Find(&questions)
+
+	return questions
+}
+
+// LockClassRoom implements QuizRepository.
+// It serialises the attempts of a user on a course until the transaction ends.
+func (repository *quizRepository) LockClassRoom(classRoomID int64) *response.Error {
+	var classRoom classRoomEntity.ClassRoom
+
+	err := repository.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&classRoom, classRoomID).
+		Error
+
+	if err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// NextQuestionPosition implements QuizRepository.
+func (repository *quizRepository) NextQuestionPosition(quizID int64) int {
+	var position int
+
+	repository.db.Model(&entity.QuizQuestion{}).
+		Where("quiz_id = ?", quizID).
+		Select("COALESCE(MAX(position), 0)").
+		Scan(&position)
+
+	return position + 1
+}
+
+// SubmitAttempt implements QuizRepository.
+// The attempt is only graded while it is still in progress, so a double
+// submission is reported as not claimed instead of being graded twice.
+func (repository *quizRepository) SubmitAttempt(attempt entity.QuizAttempt) (bool, *response.Error) {
+	claimed := false
+
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.QuizAttempt{}).
+			Where("id = ? AND status = ?", attempt.ID, entity.AttemptStatusInProgress).
+			Updates(map[string]interface{}{
+				"status":       attempt.Status,
+				"submitted_at": attempt.SubmittedAt,
+				"score":        attempt.Score,
+				"max_score":    attempt.MaxScore,
+				"percentage":   attempt.Percentage,
+				"is_passed":    attempt.IsPassed,
+			})
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		claimed = true
+
+		if len(attempt.Answers) == 0 {
+			return nil
+		}
+
+		for i := range attempt.Answers {
+			attempt.Answers[i].QuizAttemptID = attempt.ID
+		}
+
+		return tx.Create(&attempt.Answers).Error
+	})
+
+	if err != nil {
+		return false, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return claimed, nil
+}
+
+// Update implements QuizRepository.
+func (repository *quizRepository) Update(quiz entity.Quiz) (*entity.Quiz, *response.Error) {
+	if err := repository.db.Omit(clause.Associations).Save(&quiz).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &quiz, nil
+}
+
+// UpdateQuestion implements QuizRepository.
+// Options with an id are updated in place, the others are created and the
+// ones left out are soft deleted, so that attempts keep the options they
+// were shown.
+func (repository *quizRepository) UpdateQuestion(question entity.QuizQuestion) (*entity.QuizQuestion, *response.Error) {
+	err := repository.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(&question).Error; err != nil {
+			return err
+		}
+
+		kept := make([]int64, 0, len(question.Options))
+
+		for i := range question.Options {
+			option := &question.Options[i]
+			option.QuizQuestionID = question.ID
+
+			if option.ID == 0 {
+				if err := tx.Create(option).Error; err != nil {
+					return err
+				}
+			} else {
+				err := tx.Model(option).
+					Select("Content", "IsCorrect", "Position").
+					Updates(option).
+					Error
+
+				if err != nil {
+					return err
+				}
+			}
+
+			kept = append(kept, option.ID)
+		}
+
+		return tx.
+			Where("quiz_question_id = ? AND id NOT IN ?", question.ID, kept).
+			Delete(&entity.QuizQuestionOption{}).
+			Error
+	})
+
+	if err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return repository.FindQuestionById(int(question.ID))
+}
+
+// WithTx implements QuizRepository.
+func (repository *quizRepository) WithTx(tx *gorm.DB) QuizRepository {
+	return &quizRepository{tx}
+}
+
+// findOne loads a quiz with its question bank, both in position order
+func (repository *quizRepository) findOne(query *gorm.DB) (*entity.Quiz, *response.Error) {
+	var quiz entity.Quiz
+
+	err := query.
+		Preload("Questions", orderByPosition).
+		Preload("Questions.Options", orderByPosition).
+		First(&quiz).
+		Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("quiz not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &quiz, nil
+}
+
+func orderByPosition(db *gorm.DB) *gorm.DB {
+	return db.Order("position, id")
+}
+
+func NewQuizRepository(db *gorm.DB) QuizRepository {
+	return &quizRepository{db}
+}
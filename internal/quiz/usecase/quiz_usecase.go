@@ -0,0 +1,744 @@
+package quiz
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	classRoomEntity "e-course-management/internal/class_room/entity"
+	curriculumEntity "e-course-management/internal/curriculum/entity"
+	lessonProgressUseCase "e-course-management/internal/lesson_progress/usecase"
+	oauthDto "e-course-management/internal/oauth/dto"
+	dto "e-course-management/internal/quiz/dto"
+	entity "e-course-management/internal/quiz/entity"
+	repository "e-course-management/internal/quiz/repository"
+	"e-course-management/pkg/response"
+
+	"gorm.io/gorm"
+)
+
+// answers arriving shortly after the time limit are still graded, to absorb
+// network latency
+const submitGracePeriod = 15 * time.Second
+
+type QuizUseCase interface {
+	Upsert(lessonID int, request dto.QuizRequestBody) (*entity.Quiz, *response.Error)
+	FindOneById(id int) (*entity.Quiz, *response.Error)
+	CreateQuestion(quizID int, request dto.QuizQuestionRequestBody) (*entity.QuizQuestion, *response.Error)
+	UpdateQuestion(id int, request dto.QuizQuestionRequestBody) (*entity.QuizQuestion, *response.Error)
+	DeleteQuestion(id int, deletedBy int64) *response.Error
+	FindAttempts(quizID int, userID int64, offset int, limit int) ([]entity.QuizAttempt, *response.Error)
+	FindByLesson(lessonID int, userID int64) (*dto.QuizResponse, *response.Error)
+	Start(lessonID int, userID int64) (*dto.QuizAttemptResponse, *response.Error)
+	Submit(attemptID int, userID int64, request dto.QuizSubmitRequestBody) (*dto.QuizAttemptResponse, *response.Error)
+	FindAttemptById(id int, user *oauthDto.ClaimsResponse) (*dto.QuizAttemptResponse, *response.Error)
+}
+
+type quizUseCase struct {
+	db                    *gorm.DB
+	repository            repository.QuizRepository
+	lessonProgressUseCase lessonProgressUseCase.LessonProgressUseCase
+}
+
+// CreateQuestion implements QuizUseCase.
+func (usecase *quizUseCase) CreateQuestion(quizID int, request dto.QuizQuestionRequestBody) (*entity.QuizQuestion, *response.Error) {
+	quiz, err := usecase.repository.FindOneById(quizID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := questionOptions(request, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	question := entity.QuizQuestion{
+		QuizID:      quiz.ID,
+		Type:        request.Type,
+		Question:    request.Question,
+		Points:      request.Points,
+		Position:    usecase.repository.NextQuestionPosition(quiz.ID),
+		Options:     options,
+		CreatedByID: request.CreatedBy,
+	}
+
+	return usecase.repository.CreateQuestion(question)
+}
+
+// DeleteQuestion implements QuizUseCase.
+// Attempts already drawn with the question keep showing and grading it.
+func (usecase *quizUseCase) DeleteQuestion(id int, deletedBy int64) *response.Error {
+	question, err := usecase.repository.FindQuestionById(id)
+
+	if err != nil {
+		return err
+	}
+
+	question.UpdatedByID = &deletedBy
+
+	return usecase.repository.DeleteQuestion(*question)
+}
+
+// FindAttemptById implements QuizUseCase.
+// Users can only see their own attempts, admins can see every attempt.
+func (usecase *quizUseCase) FindAttemptById(id int, user *oauthDto.ClaimsResponse) (*dto.QuizAttemptResponse, *response.Error) {
+	attempt, err := usecase.repository.FindAttemptById(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsAdmin && attempt.UserID != user.ID {
+		return nil, &response.Error{
+			Code: 404,
+			Err:  errors.New("quiz attempt not found"),
+		}
+	}
+
+	quiz, err := usecase.repository.FindOneById(int(attempt.QuizID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := usecase.expire(usecase.repository, attempt, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return usecase.attemptResponse(*attempt, *quiz), nil
+}
+
+// FindAttempts implements QuizUseCase.
+func (usecase *quizUseCase) FindAttempts(quizID int, userID int64, offset int, limit int) ([]entity.QuizAttempt, *response.Error) {
+	quiz, err := usecase.repository.FindOneById(quizID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return usecase.repository.FindAttempts(quiz.ID, userID, offset, limit), nil
+}
+
+// FindByLesson implements QuizUseCase.
+func (usecase *quizUseCase) FindByLesson(lessonID int, userID int64) (*dto.QuizResponse, *response.Error) {
+	quiz, classRoom, err := usecase.findForLesson(lessonID, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := usecase.repository.FindAttemptsByClassRoomId(quiz.ID, classRoom.ID)
+	now := time.Now()
+
+	result := &dto.QuizResponse{
+		ID:            quiz.ID,
+		LessonID:      quiz.LessonID,
+		PassingScore:  quiz.PassingScore,
+		MaxAttempts:   quiz.MaxAttempts,
+		TimeLimit:     quiz.TimeLimit,
+		QuestionCount: drawCount(*quiz),
+		AttemptsUsed:  len(attempts),
+		Attempts:      attempts,
+	}
+
+	for i := range attempts {
+		if err := usecase.expire(usecase.repository, &attempts[i], now); err != nil {
+			return nil, err
+		}
+
+		attempt := attempts[i]
+
+		if attempt.Status == entity.AttemptStatusInProgress {
+			result.InProgressAttemptID = &attempt.ID
+		}
+
+		if attempt.Status == entity.AttemptStatusSubmitted && attempt.Percentage > result.BestPercentage {
+			result.BestPercentage = attempt.Percentage
+		}
+
+		if attempt.IsPassed {
+			result.IsPassed = true
+		}
+	}
+
+	if quiz.MaxAttempts != nil {
+		left := *quiz.MaxAttempts - len(attempts)
+
+		if left < 0 {
+			left = 0
+		}
+
+		result.AttemptsLeft = &left
+	}
+
+	if result.Attempts == nil {
+		result.Attempts = []entity.QuizAttempt{}
+	}
+
+	return result, nil
+}
+
+// FindOneById implements QuizUseCase.
+func (usecase *quizUseCase) FindOneById(id int) (*entity.Quiz, *response.Error) {
+	return usecase.repository.FindOneById(id)
+}
+
+// Start implements QuizUseCase.
+// An attempt that is still running is resumed instead of starting a new one.
+// The class room row is locked so that concurrent requests cannot exceed the
+// attempt limit.
+func (usecase *quizUseCase) Start(lessonID int, userID int64) (*dto.QuizAttemptResponse, *response.Error) {
+	quiz, classRoom, err := usecase.findForLesson(lessonID, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(quiz.Questions) == 0 {
+		return nil, &response.Error{
+			Code: 400,
+			Err:  errors.New("quiz has no questions yet"),
+		}
+	}
+
+	var attempt *entity.QuizAttempt
+
+	errTx := usecase.db.Transaction(func(tx *gorm.DB) error {
+		txRepository := usecase.repository.WithTx(tx)
+
+		if err := txRepository.LockClassRoom(classRoom.ID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		attempts := txRepository.FindAttemptsByClassRoomId(quiz.ID, classRoom.ID)
+
+		for i := range attempts {
+			if err := usecase.expire(txRepository, &attempts[i], now); err != nil {
+				return err
+			}
+
+			if attempts[i].Status == entity.AttemptStatusInProgress {
+				attempt = &attempts[i]
+				return nil
+			}
+		}
+
+		if quiz.MaxAttempts != nil && len(attempts) >= *quiz.MaxAttempts {
+			return &response.Error{
+				Code: 409,
+				Err:  errors.New("no attempts left for this quiz"),
+			}
+		}
+
+		questions := drawQuestions(*quiz)
+
+		newAttempt := entity.QuizAttempt{
+			QuizID:      quiz.ID,
+			UserID:      userID,
+			ClassRoomID: classRoom.ID,
+			Status:      entity.AttemptStatusInProgress,
+			QuestionIDs: make([]int64, 0, len(questions)),
+			StartedAt:   now,
+		}
+
+		for _, question := range questions {
+			newAttempt.QuestionIDs = append(newAttempt.QuestionIDs, question.ID)
+			newAttempt.MaxScore += question.Points
+		}
+
+		if quiz.TimeLimit != nil {
+			expiresAt := now.Add(time.Duration(*quiz.TimeLimit) * time.Second)
+			newAttempt.ExpiresAt = &expiresAt
+		}
+
+		created, err := txRepository.CreateAttempt(newAttempt)
+
+		if err != nil {
+			return err
+		}
+
+		attempt = created
+
+		return nil
+	})
+
+	if errTx != nil {
+		return nil, response.FromError(errTx)
+	}
+
+	return usecase.attemptResponse(*attempt, *quiz), nil
+}
+
+// Submit implements QuizUseCase.
+// Attempts submitted after their time limit are closed as expired without a
+// score. Passing the quiz completes its lesson in the same transaction.
+func (usecase *quizUseCase) Submit(attemptID int, userID int64, request dto.QuizSubmitRequestBody) (*dto.QuizAttemptResponse, *response.Error) {
+	attempt, err := usecase.repository.FindAttemptById(attemptID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if attempt.UserID != userID {
+		return nil, &response.Error{
+			Code: 404,
+			Err:  errors.New("quiz attempt not found"),
+		}
+	}
+
+	quiz, err := usecase.repository.FindOneById(int(attempt.QuizID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if err := usecase.expire(usecase.repository, attempt, now); err != nil {
+		return nil, err
+	}
+
+	switch attempt.Status {
+	case entity.AttemptStatusExpired:
+		return nil, &response.Error{
+			Code: 409,
+			Err:  errors.New("the time limit of this attempt has passed"),
+		}
+	case entity.AttemptStatusSubmitted:
+		return nil, &response.Error{
+			Code: 409,
+			Err:  errors.New("quiz attempt has already been submitted"),
+		}
+	}
+
+	answers, score, maxScore := grade(usecase.repository.FindQuestionsByIds(attempt.QuestionIDs), request.Answers)
+
+	attempt.Status = entity.AttemptStatusSubmitted
+	attempt.SubmittedAt = &now
+	attempt.Score = score
+	attempt.MaxScore = maxScore
+	attempt.Answers = answers
+
+	if maxScore > 0 {
+		attempt.Percentage = score * 100 / maxScore
+	}
+
+	attempt.IsPassed = attempt.Percentage >= quiz.PassingScore
+
+	errTx := usecase.db.Transaction(func(tx *gorm.DB) error {
+		claimed, err := usecase.repository.WithTx(tx).SubmitAttempt(*attempt)
+
+		if err != nil {
+			return err
+		}
+
+		if !claimed {
+			return &response.Error{
+				Code: 409,
+				Err:  errors.New("quiz attempt has already been submitted"),
+			}
+		}
+
+		if !attempt.IsPassed {
+			return nil
+		}
+
+		if _, err := usecase.lessonProgressUseCase.WithTx(tx).Complete(quiz.LessonID, userID); err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	if errTx != nil {
+		return nil, response.FromError(errTx)
+	}
+
+	return usecase.attemptResponse(*attempt, *quiz), nil
+}
+
+// UpdateQuestion implements QuizUseCase.
+func (usecase *quizUseCase) UpdateQuestion(id int, request dto.QuizQuestionRequestBody) (*entity.QuizQuestion, *response.Error) {
+	question, err := usecase.repository.FindQuestionById(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := questionOptions(request, question.Options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	question.Type = request.Type
+	question.Question = request.Question
+	question.Points = request.Points
+	question.Options = options
+	question.UpdatedByID = request.UpdatedBy
+
+	return usecase.repository.UpdateQuestion(*question)
+}
+
+// Upsert implements QuizUseCase.
+// A quiz lesson has a single quiz, created on the first call.
+func (usecase *quizUseCase) Upsert(lessonID int, request dto.QuizRequestBody) (*entity.Quiz, *response.Error) {
+	lesson, err := usecase.repository.FindLessonById(lessonID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if lesson.Type != curriculumEntity.LessonTypeQuiz {
+		return nil, &response.Error{
+			Code: 400,
+			Err:  errors.New("only quiz lessons can have a quiz"),
+		}
+	}
+
+	quiz, err := usecase.repository.FindOneByLessonId(lesson.ID)
+
+	if err != nil && err.Code != 404 {
+		return nil, err
+	}
+
+	if quiz == nil {
+		return usecase.repository.Create(entity.Quiz{
+			LessonID:      lesson.ID,
+			PassingScore:  request.PassingScore,
+			MaxAttempts:   request.MaxAttempts,
+			TimeLimit:     request.TimeLimit,
+			QuestionCount: request.QuestionCount,
+			CreatedByID:   request.CreatedBy,
+		})
+	}
+
+	quiz.PassingScore = request.PassingScore
+	quiz.MaxAttempts = request.MaxAttempts
+	quiz.TimeLimit = request.TimeLimit
+	quiz.QuestionCount = request.QuestionCount
+	quiz.UpdatedByID = request.UpdatedBy
+
+	return usecase.repository.Update(*quiz)
+}
+
+// attemptResponse lists the questions in the order they were drawn, with the
+// options shuffled the same way every time the attempt is shown. Correct
+// answers are never included.
+func (usecase *quizUseCase) attemptResponse(attempt entity.QuizAttempt, quiz entity.Quiz) *dto.QuizAttemptResponse {
+	result := &dto.QuizAttemptResponse{
+		ID:           attempt.ID,
+		QuizID:       attempt.QuizID,
+		LessonID:     quiz.LessonID,
+		Status:       attempt.Status,
+		StartedAt:    attempt.StartedAt,
+		ExpiresAt:    attempt.ExpiresAt,
+		SubmittedAt:  attempt.SubmittedAt,
+		Score:        attempt.Score,
+		MaxScore:     attempt.MaxScore,
+		Percentage:   attempt.Percentage,
+		PassingScore: quiz.PassingScore,
+		IsPassed:     attempt.IsPassed,
+		Questions:    []dto.QuizAttemptQuestion{},
+	}
+
+	questions := make(map[int64]entity.QuizQuestion, len(attempt.QuestionIDs))
+
+	for _, question := range usecase.repository.FindQuestionsByIds(attempt.QuestionIDs) {
+		questions[question.ID] = question
+	}
+
+	answers := make(map[int64]entity.QuizAttemptAnswer, len(attempt.Answers))
+
+	for _, answer := range attempt.Answers {
+		answers[answer.QuizQuestionID] = answer
+	}
+
+	for _, id := range attempt.QuestionIDs {
+		question, ok := questions[id]
+
+		if !ok {
+			continue
+		}
+
+		item := dto.QuizAttemptQuestion{
+			ID:       question.ID,
+			Type:     question.Type,
+			Question: question.Question,
+			Points:   question.Points,
+			Options:  []dto.QuizAttemptOption{},
+		}
+
+		// the options of a short answer question are its accepted answers
+		if question.Type != entity.QuestionTypeShortAnswer {
+			for _, option := range question.Options {
+				item.Options = append(item.Options, dto.QuizAttemptOption{
+					ID:      option.ID,
+					Content: option.Content,
+				})
+			}
+
+			random := rand.New(rand.NewSource(attempt.ID<<20 ^ question.ID))
+			random.Shuffle(len(item.Options), func(i, j int) {
+				item.Options[i], item.Options[j] = item.Options[j], item.Options[i]
+			})
+		}
+
+		if attempt.Status == entity.AttemptStatusSubmitted {
+			answer := answers[question.ID]
+
+			item.Result = &dto.QuizAttemptQuestionResult{
+				OptionIDs: answer.OptionIDs,
+				Answer:    answer.Answer,
+				IsCorrect: answer.IsCorrect,
+				Points:    answer.Points,
+			}
+		}
+
+		result.Questions = append(result.Questions, item)
+	}
+
+	return result
+}
+
+// expire closes an attempt whose time limit and grace period have passed
+func (usecase *quizUseCase) expire(repository repository.QuizRepository, attempt *entity.QuizAttempt, now time.Time) *response.Error {
+	if attempt.Status != entity.AttemptStatusInProgress || attempt.ExpiresAt == nil {
+		return nil
+	}
+
+	if !now.After(attempt.ExpiresAt.Add(submitGracePeriod)) {
+		return nil
+	}
+
+	if _, err := repository.ExpireAttempt(attempt.ID); err != nil {
+		return err
+	}
+
+	attempt.Status = entity.AttemptStatusExpired
+
+	return nil
+}
+
+// findForLesson loads the quiz of a lesson and the class room of the user
+// taking it
+func (usecase *quizUseCase) findForLesson(lessonID int, userID int64) (*entity.Quiz, *classRoomEntity.ClassRoom, *response.Error) {
+	lesson, err := usecase.repository.FindLessonById(lessonID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if lesson.Section == nil || lesson.Type != curriculumEntity.LessonTypeQuiz {
+		return nil, nil, &response.Error{
+			Code: 404,
+			Err:  errors.New("quiz not found"),
+		}
+	}
+
+	classRoom, err := usecase.repository.FindClassRoom(userID, lesson.Section.ProductID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	quiz, err := usecase.repository.FindOneByLessonId(lesson.ID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return quiz, classRoom, nil
+}
+
+// questionOptions checks the options against the question type. Every
+// option of a short answer question is an accepted answer. Option ids must
+// point at one of the existing options, each at most once.
+func questionOptions(request dto.QuizQuestionRequestBody, existing []entity.QuizQuestionOption) ([]entity.QuizQuestionOption, *response.Error) {
+	options := make([]entity.QuizQuestionOption, 0, len(request.Options))
+	correct := 0
+
+	unused := make(map[int64]bool, len(existing))
+
+	for _, option := range existing {
+		unused[option.ID] = true
+	}
+
+	for i, option := range request.Options {
+		isCorrect := option.IsCorrect || request.Type == entity.QuestionTypeShortAnswer
+
+		if isCorrect {
+			correct++
+		}
+
+		var id int64
+
+		if option.ID != nil {
+			if !unused[*option.ID] {
+				return nil, &response.Error{
+					Code: 400,
+					Err:  fmt.Errorf("option %d is not an option of this question", *option.ID),
+				}
+			}
+
+			id = *option.ID
+			unused[id] = false
+		}
+
+		options = append(options, entity.QuizQuestionOption{
+			ID:        id,
+			Content:   strings.TrimSpace(option.Content),
+			IsCorrect: isCorrect,
+			Position:  i + 1,
+		})
+	}
+
+	if request.Type == entity.QuestionTypeShortAnswer {
+		return options, nil
+	}
+
+	if len(options) < 2 {
+		return nil, &response.Error{
+			Code: 400,
+			Err:  errors.New("choice questions need at least two options"),
+		}
+	}
+
+	if request.Type == entity.QuestionTypeSingleChoice && correct != 1 {
+		return nil, &response.Error{
+			Code: 400,
+			Err:  errors.New("single choice questions need exactly one correct option"),
+		}
+	}
+
+	if correct == 0 {
+		return nil, &response.Error{
+			Code: 400,
+			Err:  errors.New("multiple choice questions need at least one correct option"),
+		}
+	}
+
+	return options, nil
+}
+
+// drawQuestions shuffles the question bank and keeps as many questions as
+// the quiz asks for
+func drawQuestions(quiz entity.Quiz) []entity.QuizQuestion {
+	questions := make([]entity.QuizQuestion, len(quiz.Questions))
+	copy(questions, quiz.Questions)
+
+	rand.Shuffle(len(questions), func(i, j int) {
+		questions[i], questions[j] = questions[j], questions[i]
+	})
+
+	return questions[:drawCount(quiz)]
+}
+
+func drawCount(quiz entity.Quiz) int {
+	if quiz.QuestionCount != nil && *quiz.QuestionCount < len(quiz.Questions) {
+		return *quiz.QuestionCount
+	}
+
+	return len(quiz.Questions)
+}
+
+// grade scores the answers of the drawn questions. Choice questions must be
+// answered with exactly the set of correct options; short answers are
+// compared ignoring case and extra whitespace.
+func grade(questions []entity.QuizQuestion, answers []dto.QuizAnswerRequestBody) ([]entity.QuizAttemptAnswer, int, int) {
+	byQuestion := make(map[int64]dto.QuizAnswerRequestBody, len(answers))
+
+	for _, answer := range answers {
+		byQuestion[answer.QuestionID] = answer
+	}
+
+	var graded []entity.QuizAttemptAnswer
+	score, maxScore := 0, 0
+
+	for _, question := range questions {
+		maxScore += question.Points
+
+		answer, ok := byQuestion[question.ID]
+
+		if !ok {
+			continue
+		}
+
+		result := entity.QuizAttemptAnswer{
+			QuizQuestionID: question.ID,
+			OptionIDs:      answer.OptionIDs,
+			Answer:         answer.Answer,
+			IsCorrect:      isCorrect(question, answer),
+		}
+
+		if result.IsCorrect {
+			result.Points = question.Points
+			score += question.Points
+		}
+
+		graded = append(graded, result)
+	}
+
+	return graded, score, maxScore
+}
+
+func isCorrect(question entity.QuizQuestion, answer dto.QuizAnswerRequestBody) bool {
+	if question.Type == entity.QuestionTypeShortAnswer {
+		if answer.Answer == nil {
+			return false
+		}
+
+		given := normalizeAnswer(*answer.Answer)
+
+		for _, option := range question.Options {
+			if given != "" && normalizeAnswer(option.Content) == given {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	correct := make(map[int64]bool)
+
+	for _, option := range question.Options {
+		if option.IsCorrect {
+			correct[option.ID] = true
+		}
+	}
+
+	chosen := make(map[int64]bool, len(answer.OptionIDs))
+
+	for _, id := range answer.OptionIDs {
+		chosen[id] = true
+	}
+
+	if len(chosen) == 0 || len(chosen) != len(correct) {
+		return false
+	}
+
+	for id := range chosen {
+		if !correct[id] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func normalizeAnswer(answer string) string {
+	return strings.Join(strings.Fields(strings.ToLower(answer)), " ")
+}
+
+func NewQuizUseCase(
+	db *gorm.DB,
+	repository repository.QuizRepository,
+	lessonProgressUseCase lessonProgressUseCase.LessonProgressUseCase,
+) QuizUseCase {
+	return &quizUseCase{db, repository, lessonProgressUseCase}
+}
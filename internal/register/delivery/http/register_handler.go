@@ -4,6 +4,7 @@ import (
 	registerUseCase "e-course-management/internal/register/usecase"
 	userDto "e-course-management/internal/user/dto"
 	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -34,7 +35,9 @@ func (handler *RegisterHandler) Register(ctx *gin.Context) {
 		return
 	}
 
-	err := handler.registerUseCase.Register(registerRequestInput)
+	guestCart := utils.GetGuestCart(ctx)
+
+	merged, err := handler.registerUseCase.Register(registerRequestInput, guestCart)
 
 	if err != nil {
 		ctx.JSON(int(err.Code), response.Response(
@@ -46,6 +49,10 @@ func (handler *RegisterHandler) Register(ctx *gin.Context) {
 		return
 	}
 
+	if merged {
+		utils.SetGuestCart(ctx, "")
+	}
+
 	ctx.JSON(http.StatusCreated, response.Response(
 		http.StatusCreated,
 		http.StatusText(http.StatusCreated),
@@ -1,7 +1,6 @@
 package register
 
 type EmailVerification struct {
-	SUBJECT           string
 	EMAIL             string
 	VERIFICATION_CODE string
 }
\ No newline at end of file
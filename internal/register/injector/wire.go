@@ -4,11 +4,22 @@
 package register
 
 import (
+	cartRepository "e-course-management/internal/cart/repository"
+	cartUseCase "e-course-management/internal/cart/usecase"
+	emailOutboxRepository "e-course-management/internal/email_outbox/repository"
+	emailSuppressionRepository "e-course-management/internal/email_suppression/repository"
+	mediaRepository "e-course-management/internal/media/repository"
+	mediaUseCase "e-course-management/internal/media/usecase"
+	productRepository "e-course-management/internal/product/repository"
+	productUseCase "e-course-management/internal/product/usecase"
+	productCategoryRepository "e-course-management/internal/product_category/repository"
+	productCategoryUseCase "e-course-management/internal/product_category/usecase"
 	handler "e-course-management/internal/register/delivery/http"
 	registerUseCase "e-course-management/internal/register/usecase"
 	userRepository "e-course-management/internal/user/repository"
 	userUseCase "e-course-management/internal/user/usecase"
 	mail "e-course-management/pkg/mail/sendgrid"
+	"e-course-management/pkg/storage"
 
 	"github.com/google/wire"
 	"gorm.io/gorm"
@@ -21,6 +32,17 @@ func InitializedService(db *gorm.DB) *handler.RegisterHandler {
 		userRepository.NewUserRepository,
 		userUseCase.NewUserUseCase,
 		mail.NewMailUseCase,
+		emailOutboxRepository.NewEmailOutboxRepository,
+		emailSuppressionRepository.NewEmailSuppressionRepository,
+		cartUseCase.NewCartUseCase,
+		cartRepository.NewCartRepository,
+		productUseCase.NewProductUseCase,
+		productRepository.NewProductRepository,
+		productCategoryUseCase.NewProductCategoryUseCase,
+		productCategoryRepository.NewProductCategoryRepository,
+		mediaUseCase.NewMediaUseCase,
+		mediaRepository.NewMediaRepository,
+		storage.NewStorage,
 	)
 
 	return &handler.RegisterHandler{}
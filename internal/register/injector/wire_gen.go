@@ -7,11 +7,22 @@
 package register
 
 import (
+	"e-course-management/internal/cart/repository"
+	cart2 "e-course-management/internal/cart/usecase"
+	"e-course-management/internal/email_outbox/repository"
+	"e-course-management/internal/email_suppression/repository"
+	"e-course-management/internal/media/repository"
+	media2 "e-course-management/internal/media/usecase"
+	"e-course-management/internal/product/repository"
+	product2 "e-course-management/internal/product/usecase"
+	"e-course-management/internal/product_category/repository"
+	product_category2 "e-course-management/internal/product_category/usecase"
 	"e-course-management/internal/register/delivery/http"
 	register2 "e-course-management/internal/register/usecase"
 	"e-course-management/internal/user/repository"
 	user2 "e-course-management/internal/user/usecase"
 	"e-course-management/pkg/mail/sendgrid"
+	"e-course-management/pkg/storage"
 	"gorm.io/gorm"
 )
 
@@ -20,8 +31,19 @@ import (
 func InitializedService(db *gorm.DB) *register.RegisterHandler {
 	userRepository := user.NewUserRepository(db)
 	userUseCase := user2.NewUserUseCase(userRepository)
-	mailMail := mail.NewMailUseCase()
-	registerUseCase := register2.NewRegisterUseCase(userUseCase, mailMail)
+	emailOutboxRepository := email_outbox.NewEmailOutboxRepository(db)
+	emailSuppressionRepository := email_suppression.NewEmailSuppressionRepository(db)
+	mailMail := mail.NewMailUseCase(emailOutboxRepository, emailSuppressionRepository)
+	cartRepository := cart.NewCartRepository(db)
+	productRepository := product.NewProductRepository(db)
+	productCategoryRepository := product_category.NewProductCategoryRepository(db)
+	mediaRepository := media.NewMediaRepository(db)
+	storageStorage := storage.NewStorage()
+	mediaUseCase := media2.NewMediaUseCase(mediaRepository, storageStorage)
+	productCategoryUseCase := product_category2.NewProductCategoryUseCase(productCategoryRepository, mediaUseCase)
+	productUseCase := product2.NewProductUseCase(productRepository, productCategoryUseCase, mediaUseCase)
+	cartUseCase := cart2.NewCartUseCase(db, cartRepository, productUseCase)
+	registerUseCase := register2.NewRegisterUseCase(db, userUseCase, mailMail, cartUseCase)
 	registerHandler := register.NewRegisterHandler(registerUseCase)
 	return registerHandler
 }
@@ -1,44 +1,77 @@
 package register
 
 import (
+	cartUseCase "e-course-management/internal/cart/usecase"
 	registerDto "e-course-management/internal/register/dto"
 	userDto "e-course-management/internal/user/dto"
 	userUseCase "e-course-management/internal/user/usecase"
 	mail "e-course-management/pkg/mail/sendgrid"
 	"e-course-management/pkg/response"
+
+	"gorm.io/gorm"
 )
 
 type RegisterUseCase interface {
-	Register(dto userDto.UserRequestBody) *response.Error
+	Register(dto userDto.UserRequestBody, guestCart string) (bool, *response.Error)
 }
 
 type registerUseCase struct {
+	db          *gorm.DB
 	userUseCase userUseCase.UserUseCase
 	mail        mail.Mail
+	cartUseCase cartUseCase.CartUseCase
 }
 
 // Register implements RegisterUseCase.
-func (usecase *registerUseCase) Register(dto userDto.UserRequestBody) *response.Error {
-	user, err := usecase.userUseCase.Create(dto)
-	if err != nil {
-		return err
-	}
+// The guest cart of the visitor becomes the cart of the new user, and the
+// result tells whether it was merged.
+func (usecase *registerUseCase) Register(dto userDto.UserRequestBody, guestCart string) (bool, *response.Error) {
+	var userID int64
+
+	// User dan email verifikasi disimpan dalam satu transaksi
+	errTx := usecase.db.Transaction(func(tx *gorm.DB) error {
+		user, err := usecase.userUseCase.WithTx(tx).Create(dto)
+
+		if err != nil {
+			return err
+		}
+
+		data := registerDto.EmailVerification{
+			EMAIL:             dto.Email,
+			VERIFICATION_CODE: user.CodeVerified,
+		}
 
-	// Melakukan pengiriman melalui email dengan sendgrid
-	data := registerDto.EmailVerification{
-		SUBJECT:           "Verification Account",
-		EMAIL:             dto.Email,
-		VERIFICATION_CODE: user.CodeVerified,
+		if err := usecase.mail.WithTx(tx).SendVerification(dto.Email, user.Locale, data); err != nil {
+			return err
+		}
+
+		userID = user.ID
+
+		return nil
+	})
+
+	if errTx != nil {
+		return false, response.FromError(errTx)
 	}
 
-	go usecase.mail.SendVerification(dto.Email, data)
+	// A failed merge leaves the guest cart in place for the first login
+	if guestCart == "" {
+		return false, nil
+	}
 
-	return nil
+	return usecase.cartUseCase.MergeGuest(guestCart, userID) == nil, nil
 }
 
-func NewRegisterUseCase(userUseCase userUseCase.UserUseCase, mail mail.Mail) RegisterUseCase {
+func NewRegisterUseCase(
+	db *gorm.DB,
+	userUseCase userUseCase.UserUseCase,
+	mail mail.Mail,
+	cartUseCase cartUseCase.CartUseCase,
+) RegisterUseCase {
 	return &registerUseCase{
+		db:          db,
 		userUseCase: userUseCase,
 		mail:        mail,
+		cartUseCase: cartUseCase,
 	}
 }
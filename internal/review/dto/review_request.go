@@ -0,0 +1,21 @@
+package review
+
+type ReviewRequestBody struct {
+	Rating  int     `json:"rating" binding:"required,min=1,max=5"`
+	Comment *string `json:"comment"`
+}
+
+type ReviewModerationRequestBody struct {
+	Reason   *string `json:"reason"`
+	HiddenBy *int64  `json:"-"`
+}
+
+// ReviewFilterRequest holds the query string of the admin review list
+type ReviewFilterRequest struct {
+	Offset    int    `form:"offset"`
+	Limit     int    `form:"limit"`
+	ProductID *int64 `form:"product_id"`
+	UserID    *int64 `form:"user_id"`
+	Rating    *int   `form:"rating" binding:"omitempty,min=1,max=5"`
+	IsHidden  *bool  `form:"is_hidden"`
+}
@@ -0,0 +1,38 @@
+package review
+
+import (
+	entity "e-course-management/internal/review/entity"
+	"time"
+)
+
+// ReviewResponse is the public view of a review, without the reviewer email
+type ReviewResponse struct {
+	ID        int64      `json:"id"`
+	ProductID int64      `json:"product_id"`
+	UserID    int64      `json:"user_id"`
+	UserName  string     `json:"user_name"`
+	Rating    int        `json:"rating"`
+	Comment   *string    `json:"comment"`
+	IsHidden  bool       `json:"is_hidden"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// ProductReviewListResponse lists the visible reviews of a product.
+// Distribution counts the visible reviews per rating, keyed "1" to "5".
+type ProductReviewListResponse struct {
+	ProductID     int64            `json:"product_id"`
+	AverageRating float64          `json:"average_rating"`
+	ReviewCount   int              `json:"review_count"`
+	Distribution  map[string]int64 `json:"distribution"`
+	Reviews       []ReviewResponse `json:"reviews"`
+	Offset        int              `json:"offset"`
+	Limit         int              `json:"limit"`
+}
+
+type ReviewListResponse struct {
+	Reviews []entity.Review `json:"reviews"`
+	Total   int64           `json:"total"`
+	Offset  int             `json:"offset"`
+	Limit   int             `json:"limit"`
+}
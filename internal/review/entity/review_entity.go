@@ -0,0 +1,28 @@
+package review
+
+import (
+	admin "e-course-management/internal/admin/entity"
+	product "e-course-management/internal/product/entity"
+	user "e-course-management/internal/user/entity"
+	"time"
+)
+
+// Review of a product by an enrolled user. Hidden reviews are only visible
+// to admins and do not count toward the product rating.
+type Review struct {
+	ID           int64            `json:"id"`
+	ProductID    int64            `json:"product_id"`
+	Product      *product.Product `json:"product,omitempty" gorm:"foreignKey:ProductID;references:ID"`
+	UserID       int64            `json:"user_id"`
+	User         *user.User       `json:"user,omitempty" gorm:"foreignKey:UserID;references:ID"`
+	ClassRoomID  int64            `json:"class_room_id"`
+	Rating       int              `json:"rating"`
+	Comment      *string          `json:"comment"`
+	IsHidden     bool             `json:"is_hidden"`
+	HiddenReason *string          `json:"hidden_reason"`
+	HiddenAt     *time.Time       `json:"hidden_at"`
+	HiddenByID   *int64           `json:"hidden_by" gorm:"column:hidden_by"`
+	HiddenBy     *admin.Admin     `json:"-" gorm:"foreignKey:HiddenByID;references:ID"`
+	CreatedAt    *time.Time       `json:"created_at"`
+	UpdatedAt    *time.Time       `json:"updated_at"`
+}
@@ -0,0 +1,37 @@
+//go:build wireinject
+// +build wireinject
+
+package review
+
+import (
+	mediaRepository "e-course-management/internal/media/repository"
+	mediaUseCase "e-course-management/internal/media/usecase"
+	productRepository "e-course-management/internal/product/repository"
+	productUseCase "e-course-management/internal/product/usecase"
+	productCategoryRepository "e-course-management/internal/product_category/repository"
+	productCategoryUseCase "e-course-management/internal/product_category/usecase"
+	handler "e-course-management/internal/review/delivery/http"
+	repository "e-course-management/internal/review/repository"
+	usecase "e-course-management/internal/review/usecase"
+	"e-course-management/pkg/storage"
+
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+func InitializedService(db *gorm.DB) *handler.ReviewHandler {
+	wire.Build(
+		handler.NewReviewHandler,
+		usecase.NewReviewUseCase,
+		repository.NewReviewRepository,
+		productUseCase.NewProductUseCase,
+		productRepository.NewProductRepository,
+		productCategoryUseCase.NewProductCategoryUseCase,
+		productCategoryRepository.NewProductCategoryRepository,
+		mediaUseCase.NewMediaUseCase,
+		mediaRepository.NewMediaRepository,
+		storage.NewStorage,
+	)
+
+	return &handler.ReviewHandler{}
+}
@@ -0,0 +1,235 @@
+package review
+
+import (
+	"errors"
+
+	classRoomEntity "e-course-management/internal/class_room/entity"
+	dto "e-course-management/internal/review/dto"
+	entity "e-course-management/internal/review/entity"
+	"e-course-management/pkg/response"
+	"e-course-management/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+type ReviewRepository interface {
+	FindClassRoom(userID int64, productID int64) (*classRoomEntity.ClassRoom, *response.Error)
+	FindAll(filter dto.ReviewFilterRequest) ([]entity.Review, int64)
+	FindAllByProductId(productID int64, offset int, limit int) []entity.Review
+	FindDistributionByProductId(productID int64) map[int]int64
+	FindOneById(id int) (*entity.Review, *response.Error)
+	FindOneByUserAndProduct(userID int64, productID int64) (*entity.Review, *response.Error)
+	Create(review entity.Review) (*entity.Review, *response.Error)
+	Update(review entity.Review) (*entity.Review, *response.Error)
+	RefreshProductRating(productID int64) *response.Error
+	WithTx(tx *gorm.DB) ReviewRepository
+}
+
+type reviewRepository struct {
+	db *gorm.DB
+}
+
+// Create implements ReviewRepository.
+func (repository *reviewRepository) Create(review entity.Review) (*entity.Review, *response.Error) {
+	if err := repository.db.Create(&review).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &review, nil
+}
+
+// FindAll implements ReviewRepository.
+// It returns one page of reviews, hidden ones included, and the total match count.
+func (repository *reviewRepository) FindAll(filter dto.ReviewFilterRequest) ([]entity.Review, int64) {
+	var reviews []entity.Review
+	var total int64
+
+	query := repository.db.Model(&entity.Review{})
+
+	if filter.ProductID != nil {
+		query = query.Where("product_id = ?", *filter.ProductID)
+	}
+
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+
+	if filter.Rating != nil {
+		query = query.Where("rating = ?", *filter.Rating)
+	}
+
+	if filter.IsHidden != nil {
+		query = query.Where("is_hidden = ?", *filter.IsHidden)
+	}
+
+	query = query.Session(&gorm.Session{})
+
+	query.Count(&total)
+
+	query.Preload("User").
+		Preload("Product").
+		Scopes(utils.Paginate(filter.Offset, filter.Limit)).
+		Order("id DESC").
+		Find(&reviews)
+
+	return reviews, total
+}
+
+// FindAllByProductId implements ReviewRepository.
+// Only visible reviews are returned, newest first.
+func (repository *reviewRepository) FindAllByProductId(productID int64, offset int, limit int) []entity.Review {
+	var reviews []entity.Review
+
+	repository.db.
+		Preload("User").
+		Scopes(utils.Paginate(offset, limit)).
+		Where("product_id = ? AND is_hidden = ?", productID, false).
+		Order("created_at DESC, id DESC").
+		Find(&reviews)
+
+	return reviews
+}
+
+// FindClassRoom implements ReviewRepository.
+func (repository *reviewRepository) FindClassRoom(userID int64, productID int64) (*classRoomEntity.ClassRoom, *response.Error) {
+	var classRoom classRoomEntity.ClassRoom
+
+	err := repository.db.
+		Where("user_id = ? AND product_id = ?", userID, productID).
+		First(&classRoom).
+		Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 403,
+				Err:  errors.New("only enrolled users can review this course"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &classRoom, nil
+}
+
+// FindDistributionByProductId implements ReviewRepository.
+func (repository *reviewRepository) FindDistributionByProductId(productID int64) map[int]int64 {
+	var rows []struct {
+		Rating int
+		Count  int64
+	}
+
+	repository.db.Model(&entity.Review{}).
+		Select("rating, COUNT(*) AS count").
+		Where("product_id = ? AND is_hidden = ?", productID, false).
+		Group("rating").
+		Scan(&rows)
+
+	distribution := make(map[int]int64, len(rows))
+
+	for _, row := range rows {
+		distribution[row.Rating] = row.Count
+	}
+
+	return distribution
+}
+
+// FindOneById implements ReviewRepository.
+func (repository *reviewRepository) FindOneById(id int) (*entity.Review, *response.Error) {
+	var review entity.Review
+
+	if err := repository.db.Preload("User").First(&review, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("review not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &review, nil
+}
+
+// FindOneByUserAndProduct implements ReviewRepository.
+func (repository *reviewRepository) FindOneByUserAndProduct(userID int64, productID int64) (*entity.Review, *response.Error) {
+	var review entity.Review
+
+	err := repository.db.
+		Where("user_id = ? AND product_id = ?", userID, productID).
+		First(&review).
+		Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("review not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &review, nil
+}
+
+// RefreshProductRating implements ReviewRepository.
+// The average and count of the visible reviews are stored on the product so
+// catalog listings can read and sort by them without aggregating.
+func (repository *reviewRepository) RefreshProductRating(productID int64) *response.Error {
+	err := repository.db.Exec(
+		"UPDATE products SET "+
+			"average_rating = (SELECT COALESCE(AVG(rating), 0) FROM reviews WHERE product_id = ? AND is_hidden = 0), "+
+			"review_count = (SELECT COUNT(*) FROM reviews WHERE product_id = ? AND is_hidden = 0) "+
+			"WHERE id = ?",
+		productID, productID, productID,
+	).Error
+
+	if err != nil {
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// Update implements ReviewRepository.
+func (repository *reviewRepository) Update(review entity.Review) (*entity.Review, *response.Error) {
+	review.User = nil
+	review.Product = nil
+
+	if err := repository.db.Save(&review).Error; err != nil {
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &review, nil
+}
+
+// WithTx implements ReviewRepository.
+func (repository *reviewRepository) WithTx(tx *gorm.DB) ReviewRepository {
+	return &reviewRepository{tx}
+}
+
+func NewReviewRepository(db *gorm.DB) ReviewRepository {
+	return &reviewRepository{db}
+}
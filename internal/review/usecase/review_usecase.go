@@ -0,0 +1,246 @@
+package review
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	productUseCase "e-course-management/internal/product/usecase"
+	dto "e-course-management/internal/review/dto"
+	entity "e-course-management/internal/review/entity"
+	repository "e-course-management/internal/review/repository"
+	"e-course-management/pkg/response"
+
+	"gorm.io/gorm"
+)
+
+type ReviewUseCase interface {
+	FindAll(filter dto.ReviewFilterRequest) dto.ReviewListResponse
+	FindAllByProduct(productID int, offset int, limit int) (*dto.ProductReviewListResponse, *response.Error)
+	FindMine(productID int, userID int64) (*entity.Review, *response.Error)
+	Create(productID int, userID int64, request dto.ReviewRequestBody) (*entity.Review, *response.Error)
+	Update(id int, userID int64, request dto.ReviewRequestBody) (*entity.Review, *response.Error)
+	Hide(id int, request dto.ReviewModerationRequestBody) (*entity.Review, *response.Error)
+	Unhide(id int) (*entity.Review, *response.Error)
+}
+
+type reviewUseCase struct {
+	db             *gorm.DB
+	repository     repository.ReviewRepository
+	productUseCase productUseCase.ProductUseCase
+}
+
+// Create implements ReviewUseCase.
+// A user can review a course they are enrolled in once.
+func (usecase *reviewUseCase) Create(productID int, userID int64, request dto.ReviewRequestBody) (*entity.Review, *response.Error) {
+	product, err := usecase.productUseCase.FindOneById(productID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	classRoom, err := usecase.repository.FindClassRoom(userID, product.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := usecase.repository.FindOneByUserAndProduct(userID, product.ID); err == nil {
+		return nil, &response.Error{
+			Code: 409,
+			Err:  errors.New("you have already reviewed this course"),
+		}
+	} else if err.Code != 404 {
+		return nil, err
+	}
+
+	review := entity.Review{
+		ProductID:   product.ID,
+		UserID:      userID,
+		ClassRoomID: classRoom.ID,
+		Rating:      request.Rating,
+		Comment:     normalizeText(request.Comment),
+	}
+
+	return usecase.save(review, true)
+}
+
+// FindAll implements ReviewUseCase.
+func (usecase *reviewUseCase) FindAll(filter dto.ReviewFilterRequest) dto.ReviewListResponse {
+	reviews, total := usecase.repository.FindAll(filter)
+
+	return dto.ReviewListResponse{
+		Reviews: reviews,
+		Total:   total,
+		Offset:  filter.Offset,
+		Limit:   filter.Limit,
+	}
+}
+
+// FindAllByProduct implements ReviewUseCase.
+func (usecase *reviewUseCase) FindAllByProduct(productID int, offset int, limit int) (*dto.ProductReviewListResponse, *response.Error) {
+	product, err := usecase.productUseCase.FindOneById(productID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.ProductReviewListResponse{
+		ProductID:     product.ID,
+		AverageRating: product.AverageRating,
+		ReviewCount:   product.ReviewCount,
+		Distribution:  make(map[string]int64, 5),
+		Reviews:       []dto.ReviewResponse{},
+		Offset:        offset,
+		Limit:         limit,
+	}
+
+	distribution := usecase.repository.FindDistributionByProductId(product.ID)
+
+	for rating := 1; rating <= 5; rating++ {
+		result.Distribution[strconv.Itoa(rating)] = distribution[rating]
+	}
+
+	for _, review := range usecase.repository.FindAllByProductId(product.ID, offset, limit) {
+		result.Reviews = append(result.Reviews, reviewResponse(review))
+	}
+
+	return result, nil
+}
+
+// FindMine implements ReviewUseCase.
+func (usecase *reviewUseCase) FindMine(productID int, userID int64) (*entity.Review, *response.Error) {
+	return usecase.repository.FindOneByUserAndProduct(userID, int64(productID))
+}
+
+// Hide implements ReviewUseCase.
+func (usecase *reviewUseCase) Hide(id int, request dto.ReviewModerationRequestBody) (*entity.Review, *response.Error) {
+	review, err := usecase.repository.FindOneById(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	review.IsHidden = true
+	review.HiddenReason = normalizeText(request.Reason)
+	review.HiddenAt = &now
+	review.HiddenByID = request.HiddenBy
+
+	return usecase.save(*review, false)
+}
+
+// Unhide implements ReviewUseCase.
+func (usecase *reviewUseCase) Unhide(id int) (*entity.Review, *response.Error) {
+	review, err := usecase.repository.FindOneById(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	review.IsHidden = false
+	review.HiddenReason = nil
+	review.HiddenAt = nil
+	review.HiddenByID = nil
+
+	return usecase.save(*review, false)
+}
+
+// Update implements ReviewUseCase.
+// Editing a hidden review keeps it hidden.
+func (usecase *reviewUseCase) Update(id int, userID int64, request dto.ReviewRequestBody) (*entity.Review, *response.Error) {
+	review, err := usecase.repository.FindOneById(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if review.UserID != userID {
+		return nil, &response.Error{
+			Code: 403,
+			Err:  errors.New("you can only edit your own review"),
+		}
+	}
+
+	review.Rating = request.Rating
+	review.Comment = normalizeText(request.Comment)
+
+	return usecase.save(*review, false)
+}
+
+// save writes the review and refreshes the rating of its product in the
+// same transaction
+func (usecase *reviewUseCase) save(review entity.Review, isNew bool) (*entity.Review, *response.Error) {
+	var result *entity.Review
+
+	errTx := usecase.db.Transaction(func(tx *gorm.DB) error {
+		txRepository := usecase.repository.WithTx(tx)
+
+		var err *response.Error
+
+		if isNew {
+			result, err = txRepository.Create(review)
+		} else {
+			result, err = txRepository.Update(review)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := txRepository.RefreshProductRating(review.ProductID); err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	if errTx != nil {
+		return nil, response.FromError(errTx)
+	}
+
+	return result, nil
+}
+
+func normalizeText(text *string) *string {
+	if text == nil {
+		return nil
+	}
+
+	trimmed := strings.TrimSpace(*text)
+
+	if trimmed == "" {
+		return nil
+	}
+
+	return &trimmed
+}
+
+func reviewResponse(review entity.Review) dto.ReviewResponse {
+	result := dto.ReviewResponse{
+		ID:        review.ID,
+		ProductID: review.ProductID,
+		UserID:    review.UserID,
+		Rating:    review.Rating,
+		Comment:   review.Comment,
+		IsHidden:  review.IsHidden,
+		CreatedAt: review.CreatedAt,
+		UpdatedAt: review.UpdatedAt,
+	}
+
+	if review.User != nil {
+		result.UserName = review.User.Name
+	}
+
+	return result
+}
+
+func NewReviewUseCase(
+	db *gorm.DB,
+	repository repository.ReviewRepository,
+	productUseCase productUseCase.ProductUseCase,
+) ReviewUseCase {
+	return &reviewUseCase{db, repository, productUseCase}
+}
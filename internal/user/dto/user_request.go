@@ -6,6 +6,7 @@ type UserRequestBody struct {
 	Name      string `json:"name" binding:"required"`
 	Email     string `json:"email" binding:"email"`
 	Password  string `json:"password" binding:"required"`
+	Locale    string `json:"locale" binding:"omitempty,oneof=id en"`
 	CreatedBy *int64 `json:"created_by"`
 }
 
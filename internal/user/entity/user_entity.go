@@ -13,6 +13,10 @@ type User struct {
 	Password        string     `json:"-"`
 	CodeVerified    string     `json:"-"`
 	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	// Set when the email provider reports the address as undeliverable
+	EmailSuppressedAt      *time.Time `json:"email_suppressed_at"`
+	EmailSuppressionReason *string    `json:"email_suppression_reason"`
+	Locale                 string     `json:"locale"`
 	// CreatedByID     *int64             `json:"created_by" gorm:"column:created_by"`
 	// CreatedBy       *adminEntity.Admin `json:"-" gorm:"foreignKey:CreatedByID;references:ID"`
 	// UpdatedByID     *int64             `json:"updated_by" gorm:"column:updated_by"`
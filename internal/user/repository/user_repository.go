@@ -1,6 +1,8 @@
 package user
 
 import (
+	"errors"
+
 	entity "e-course-management/internal/user/entity"
 	"e-course-management/pkg/response"
 
@@ -16,6 +18,7 @@ type UserRepository interface {
 	Update(entity entity.User) (*entity.User, *response.Error)
 	Delete(entity entity.User) (*entity.User, *response.Error)
 	TotalCountUser() int64
+	WithTx(tx *gorm.DB) UserRepository
 }
 
 type userRepository struct {
@@ -64,8 +67,24 @@ func (*userRepository) FindOneByCodeVerified(codeVerified string) (*entity.User,
 }
 
 // FindOneById implements UserRepository.
-func (*userRepository) FindOneById(id int) (*entity.User, *response.Error) {
-	panic("unimplemented")
+func (repository *userRepository) FindOneById(id int) (*entity.User, *response.Error) {
+	var user entity.User
+
+	if err := repository.db.First(&user, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &response.Error{
+				Code: 404,
+				Err:  errors.New("user not found"),
+			}
+		}
+
+		return nil, &response.Error{
+			Code: 500,
+			Err:  err,
+		}
+	}
+
+	return &user, nil
 }
 
 // TotalCountUser implements UserRepository.
@@ -78,6 +97,11 @@ func (*userRepository) Update(entity entity.User) (*entity.User, *response.Error
 	panic("unimplemented")
 }
 
+// WithTx implements UserRepository.
+func (repository *userRepository) WithTx(tx *gorm.DB) UserRepository {
+	return &userRepository{tx}
+}
+
 func NewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepository{db}
 }
@@ -21,6 +21,7 @@ type UserUseCase interface {
 	Update(id int, dto dto.UserUpdateRequestBody) (*entity.User, *response.Error)
 	Delete(id int) *response.Error
 	TotalCountUser() int64
+	WithTx(tx *gorm.DB) UserUseCase
 }
 
 type userUseCase struct {
@@ -59,6 +60,11 @@ func (usecase *userUseCase) Create(dto dto.UserRequestBody) (*entity.User, *resp
 		Email:        dto.Email,
 		Password:     string(hashedPassword),
 		CodeVerified: utils.RandString(32),
+		Locale:       dto.Locale,
+	}
+
+	if user.Locale == "" {
+		user.Locale = "id"
 	}
 
 	dataUser, err := usecase.repository.Create(user)
@@ -94,8 +100,8 @@ func (*userUseCase) FindOneByCodeVerified(codeVerified string) (*entity.User, *r
 }
 
 // FindOneById implements UserUseCase.
-func (*userUseCase) FindOneById(id int) (*entity.User, *response.Error) {
-	panic("unimplemented")
+func (usecase *userUseCase) FindOneById(id int) (*entity.User, *response.Error) {
+	return usecase.repository.FindOneById(id)
 }
 
 // TotalCountUser implements UserUseCase.
@@ -134,6 +140,11 @@ func (usecase *userUseCase) Update(id int, dto dto.UserUpdateRequestBody) (*enti
 	return updateUser, nil
 }
 
+// WithTx implements UserUseCase.
+func (usecase *userUseCase) WithTx(tx *gorm.DB) UserUseCase {
+	return &userUseCase{usecase.repository.WithTx(tx)}
+}
+
 func NewUserUseCase(repository repository.UserRepository) UserUseCase {
 	return &userUseCase{repository}
 }
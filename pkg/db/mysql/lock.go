@@ -0,0 +1,71 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+)
+
+// Lock is a MySQL named lock. Named locks belong to the session that took
+// them, so the lock keeps its own connection out of the pool until Release.
+// MySQL frees the lock by itself when that connection is lost.
+type Lock struct {
+	name string
+	conn *sql.Conn
+}
+
+// TryLock takes the named lock without waiting. It returns nil, nil when
+// another session holds the lock.
+func TryLock(db *gorm.DB, name string) (*Lock, error) {
+	sqlDB, err := db.DB()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+
+	conn, err := sqlDB.Conn(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var acquired sql.NullInt64
+
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", name).Scan(&acquired); err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	if acquired.Int64 != 1 {
+		conn.Close()
+
+		return nil, nil
+	}
+
+	return &Lock{name, conn}, nil
+}
+
+// Held tells whether the lock is still held, which stops being the case
+// once its connection was lost.
+func (lock *Lock) Held() bool {
+	var held sql.NullInt64
+
+	err := lock.conn.
+		QueryRowContext(context.Background(), "SELECT IS_USED_LOCK(?) = CONNECTION_ID()", lock.name).
+		Scan(&held)
+
+	return err == nil && held.Int64 == 1
+}
+
+// Release frees the lock and gives its connection back to the pool
+func (lock *Lock) Release() error {
+	defer lock.conn.Close()
+
+	_, err := lock.conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", lock.name)
+
+	return err
+}
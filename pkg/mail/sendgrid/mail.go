@@ -1,88 +1,120 @@
 package mail
 
 import (
-	"bytes"
-	"fmt"
-	"html/template"
-	"os"
-	"path/filepath"
-
-	"github.com/sendgrid/sendgrid-go"
-	"github.com/sendgrid/sendgrid-go/helpers/mail"
+	"time"
+
+	emailOutboxEntity "e-course-management/internal/email_outbox/entity"
+	emailOutboxRepository "e-course-management/internal/email_outbox/repository"
+	emailSuppressionRepository "e-course-management/internal/email_suppression/repository"
 	forgotPasswordDto "e-course-management/internal/forgot_password/dto"
+	lessonProgressDto "e-course-management/internal/lesson_progress/dto"
+	orderNotificationDto "e-course-management/internal/order_notification/dto"
 	registerDto "e-course-management/internal/register/dto"
+	"e-course-management/pkg/response"
+
+	"gorm.io/gorm"
 )
 
+const defaultMaxAttempts = 5
+
+// Mail renders emails and queues them in the email outbox. Delivery happens
+// asynchronously in the outbox worker, so queueing inside a transaction ties
+// the email to the domain change that triggered it. Emails to suppressed
+// addresses are recorded but never delivered.
 type Mail interface {
-	SendVerification(toEmail string, data registerDto.EmailVerification)
-	SendForgotPassword(toEmail string, data forgotPasswordDto.ForgotPasswordEmailRequestBody)
+	SendVerification(toEmail string, locale string, data registerDto.EmailVerification) *response.Error
+	SendForgotPassword(toEmail string, locale string, data forgotPasswordDto.ForgotPasswordEmailRequestBody) *response.Error
+	SendOrderReceipt(toEmail string, locale string, data orderNotificationDto.OrderReceiptEmail) *response.Error
+	SendPaymentReminder(toEmail string, locale string, data orderNotificationDto.PaymentReminderEmail) *response.Error
+	SendAccessGranted(toEmail string, locale string, data orderNotificationDto.AccessGrantedEmail) *response.Error
+	SendCourseCompleted(toEmail string, locale string, data lessonProgressDto.CourseCompletedEmail) *response.Error
+	WithTx(tx *gorm.DB) Mail
 }
 
 type mailUsecase struct {
+	emailOutboxRepository      emailOutboxRepository.EmailOutboxRepository
+	emailSuppressionRepository emailSuppressionRepository.EmailSuppressionRepository
 }
 
 // SendForgotPassword implements Mail
-func (usecase *mailUsecase) SendForgotPassword(toEmail string, data forgotPasswordDto.ForgotPasswordEmailRequestBody) {
-	cwd, _ := os.Getwd()
-	templateFile := filepath.Join(cwd, "/templates/emails/forgot_password.html")
-
-	result, err := ParseTemplate(templateFile, data)
-
-	if err != nil {
-		fmt.Println(err)
-	} else {
-		usecase.sendMail(toEmail, result, data.SUBJECT)
-	}
+func (usecase *mailUsecase) SendForgotPassword(toEmail string, locale string, data forgotPasswordDto.ForgotPasswordEmailRequestBody) *response.Error {
+	return usecase.enqueue(toEmail, "forgot_password", locale, data)
 }
 
 // SendVerification implements Mail
-func (usecase *mailUsecase) SendVerification(toEmail string, data registerDto.EmailVerification) {
-	cwd, _ := os.Getwd()
-	templateFile := filepath.Join(cwd, "/templates/emails/verification_email.html")
-
-	result, err := ParseTemplate(templateFile, data)
+func (usecase *mailUsecase) SendVerification(toEmail string, locale string, data registerDto.EmailVerification) *response.Error {
+	return usecase.enqueue(toEmail, "verification_email", locale, data)
+}
 
-	if err != nil {
-		fmt.Println(err)
-	} else {
-		usecase.sendMail(toEmail, result, data.SUBJECT)
-	}
+// SendOrderReceipt implements Mail
+func (usecase *mailUsecase) SendOrderReceipt(toEmail string, locale string, data orderNotificationDto.OrderReceiptEmail) *response.Error {
+	return usecase.enqueue(toEmail, "order_receipt", locale, data)
 }
 
-func (usecase *mailUsecase) sendMail(toEmail string, result string, subject string) {
-	from := mail.NewEmail(os.Getenv("MAIL_SENDER_NAME"), os.Getenv("MAIL_SENDER_NAME"))
-	to := mail.NewEmail(toEmail, toEmail)
+// SendPaymentReminder implements Mail
+func (usecase *mailUsecase) SendPaymentReminder(toEmail string, locale string, data orderNotificationDto.PaymentReminderEmail) *response.Error {
+	return usecase.enqueue(toEmail, "payment_reminder", locale, data)
+}
 
-	message := mail.NewSingleEmail(from, subject, to, "", result)
+// SendAccessGranted implements Mail
+func (usecase *mailUsecase) SendAccessGranted(toEmail string, locale string, data orderNotificationDto.AccessGrantedEmail) *response.Error {
+	return usecase.enqueue(toEmail, "access_granted", locale, data)
+}
 
-	client := sendgrid.NewSendClient(os.Getenv("MAIL_KEY"))
-	resp, err := client.Send(message)
+// SendCourseCompleted implements Mail
+func (usecase *mailUsecase) SendCourseCompleted(toEmail string, locale string, data lessonProgressDto.CourseCompletedEmail) *response.Error {
+	return usecase.enqueue(toEmail, "course_completed", locale, data)
+}
 
-	if err != nil {
-		fmt.Println(err)
-	} else if resp.StatusCode != 200 {
-		fmt.Println(resp)
-	} else {
-		fmt.Printf("success send email to %s", toEmail)
+// WithTx implements Mail
+func (usecase *mailUsecase) WithTx(tx *gorm.DB) Mail {
+	return &mailUsecase{
+		usecase.emailOutboxRepository.WithTx(tx),
+		usecase.emailSuppressionRepository,
 	}
 }
 
-func ParseTemplate(templateFileName string, data interface{}) (string, error) {
-	t, err := template.ParseFiles(templateFileName)
+func (usecase *mailUsecase) enqueue(toEmail string, templateName string, locale string, data interface{}) *response.Error {
+	result, err := ParseTemplate(templateName, locale, data)
 
 	if err != nil {
-		return "", err
+		return &response.Error{
+			Code: 500,
+			Err:  err,
+		}
 	}
 
-	buf := new(bytes.Buffer)
+	if locale == "" {
+		locale = DefaultLocale
+	}
+
+	now := time.Now()
+
+	emailOutbox := emailOutboxEntity.EmailOutbox{
+		ToEmail:       toEmail,
+		Subject:       result.Subject,
+		Template:      templateName,
+		Locale:        locale,
+		HtmlBody:      result.Html,
+		TextBody:      result.Text,
+		Status:        emailOutboxEntity.StatusPending,
+		MaxAttempts:   defaultMaxAttempts,
+		NextAttemptAt: &now,
+	}
 
-	if err = t.Execute(buf, data); err != nil {
-		return "", err
+	if usecase.emailSuppressionRepository.IsSuppressed(toEmail) {
+		emailOutbox.Status = emailOutboxEntity.StatusSuppressed
+		emailOutbox.NextAttemptAt = nil
 	}
 
-	return buf.String(), nil
+	_, errCreate := usecase.emailOutboxRepository.Create(emailOutbox)
+
+	return errCreate
 }
 
-func NewMailUseCase() Mail {
-	return &mailUsecase{}
+func NewMailUseCase(
+	emailOutboxRepository emailOutboxRepository.EmailOutboxRepository,
+	emailSuppressionRepository emailSuppressionRepository.EmailSuppressionRepository,
+) Mail {
+	return &mailUsecase{emailOutboxRepository, emailSuppressionRepository}
 }
@@ -0,0 +1,269 @@
+package mail
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmlTemplate "html/template"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	textTemplate "text/template"
+
+	"e-course-management/templates"
+)
+
+// DefaultLocale is used when a user has no locale or the template has no
+// variant for it
+const DefaultLocale = "id"
+
+type TemplateInfo struct {
+	Name    string   `json:"name"`
+	Locales []string `json:"locales"`
+}
+
+type RenderedEmail struct {
+	Subject string `json:"subject"`
+	Html    string `json:"html"`
+	Text    string `json:"text"`
+}
+
+// emailTemplate holds a template file parsed twice: the HTML body goes
+// through html/template, the plain text subject and text part through
+// text/template so that they are not entity escaped.
+type emailTemplate struct {
+	html *htmlTemplate.Template
+	text *textTemplate.Template
+}
+
+// emailTemplates maps locale -> template name -> parsed template. It is built
+// once at startup from the embedded templates.
+var emailTemplates = mustParseTemplates(templates.Emails)
+
+func mustParseTemplates(fsys fs.FS) map[string]map[string]emailTemplate {
+	parsed, err := parseTemplates(fsys)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}
+
+func parseTemplates(fsys fs.FS) (map[string]map[string]emailTemplate, error) {
+	layout, err := htmlTemplate.New("layout").
+		Funcs(htmlTemplate.FuncMap{
+			"locale": func() string { return DefaultLocale },
+			"rupiah": rupiahFunc,
+		}).
+		ParseFS(fsys, "emails/layouts/*.html")
+
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := fs.ReadDir(fsys, "emails")
+
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := map[string]map[string]emailTemplate{}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == "layouts" || entry.Name() == "samples" {
+			continue
+		}
+
+		locale := entry.Name()
+
+		files, err := fs.Glob(fsys, path.Join("emails", locale, "*.html"))
+
+		if err != nil {
+			return nil, err
+		}
+
+		parsed[locale] = map[string]emailTemplate{}
+
+		for _, file := range files {
+			h, err := layout.Clone()
+
+			if err != nil {
+				return nil, err
+			}
+
+			h, err = h.Funcs(htmlTemplate.FuncMap{"locale": func() string { return locale }}).ParseFS(fsys, file)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if h.Lookup("subject") == nil || h.Lookup("content") == nil {
+				return nil, fmt.Errorf("%s must define both a subject and a content block", file)
+			}
+
+			t, err := textTemplate.New(path.Base(file)).
+				Funcs(textTemplate.FuncMap{
+					"locale": func() string { return locale },
+					"rupiah": rupiahFunc,
+				}).
+				ParseFS(fsys, file)
+
+			if err != nil {
+				return nil, err
+			}
+
+			parsed[locale][strings.TrimSuffix(path.Base(file), ".html")] = emailTemplate{html: h, text: t}
+		}
+	}
+
+	return parsed, nil
+}
+
+// ParseTemplate renders the named template in the given locale, falling back to
+// DefaultLocale. The text part comes from an optional "text" block and is
+// otherwise generated from the HTML.
+func ParseTemplate(name string, locale string, data interface{}) (*RenderedEmail, error) {
+	t, ok := emailTemplates[locale][name]
+
+	if !ok {
+		t, ok = emailTemplates[DefaultLocale][name]
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("email template %q not found", name)
+	}
+
+	subject, err := execute(t.text, "subject", data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	html, err := execute(t.html, "layout", data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var text string
+
+	if t.text.Lookup("text") != nil {
+		text, err = execute(t.text, "text", data)
+
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		text = HtmlToText(html)
+	}
+
+	return &RenderedEmail{
+		Subject: strings.TrimSpace(subject),
+		Html:    html,
+		Text:    strings.TrimSpace(text),
+	}, nil
+}
+
+// Rupiah formats an amount as Indonesian currency, e.g. Rp 150.000
+func Rupiah(amount int64) string {
+	sign := ""
+
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := fmt.Sprintf("%d", amount)
+
+	var grouped strings.Builder
+
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+
+		grouped.WriteRune(digit)
+	}
+
+	return sign + "Rp " + grouped.String()
+}
+
+// rupiahFunc also accepts the float64 amounts of JSON supplied preview data
+func rupiahFunc(amount interface{}) string {
+	switch value := amount.(type) {
+	case int64:
+		return Rupiah(value)
+	case int:
+		return Rupiah(int64(value))
+	case float64:
+		return Rupiah(int64(value))
+	default:
+		return fmt.Sprint(amount)
+	}
+}
+
+// templateExecutor is satisfied by both html and text templates
+type templateExecutor interface {
+	ExecuteTemplate(wr io.Writer, name string, data any) error
+}
+
+func execute(t templateExecutor, name string, data interface{}) (string, error) {
+	buf := new(bytes.Buffer)
+
+	if err := t.ExecuteTemplate(buf, name, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// ListTemplates returns every template name with the locales it is available in
+func ListTemplates() []TemplateInfo {
+	locales := map[string][]string{}
+
+	for locale, byName := range emailTemplates {
+		for name := range byName {
+			locales[name] = append(locales[name], locale)
+		}
+	}
+
+	templateInfos := []TemplateInfo{}
+
+	for name, available := range locales {
+		sort.Strings(available)
+		templateInfos = append(templateInfos, TemplateInfo{Name: name, Locales: available})
+	}
+
+	sort.Slice(templateInfos, func(i, j int) bool {
+		return templateInfos[i].Name < templateInfos[j].Name
+	})
+
+	return templateInfos
+}
+
+// SampleData loads the example data shipped in emails/samples for a template
+func SampleData(name string) (map[string]interface{}, error) {
+	content, err := fs.ReadFile(templates.Emails, path.Join("emails", "samples", name+".json"))
+
+	if err != nil {
+		return nil, fmt.Errorf("no sample data for email template %q", name)
+	}
+
+	var data map[string]interface{}
+
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// TemplateExists reports whether the template is defined in the default locale
+func TemplateExists(name string) bool {
+	_, ok := emailTemplates[DefaultLocale][name]
+
+	return ok
+}
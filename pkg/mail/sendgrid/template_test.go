@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTemplateDoesNotEscapePlainText(t *testing.T) {
+	tests := []struct {
+		locale  string
+		subject string
+	}{
+		{"en", "Congratulations on Completing Go & Gin's Course"},
+		{"id", "Selamat, Anda Telah Menyelesaikan Go & Gin's Course"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.locale, func(t *testing.T) {
+			rendered, err := ParseTemplate("course_completed", test.locale, map[string]interface{}{
+				"NAME":         "Budi <Santoso>",
+				"COURSE":       "Go & Gin's Course",
+				"COMPLETED_AT": "19 October 2026 14:30",
+			})
+
+			if err != nil {
+				t.Fatalf("parse template: %v", err)
+			}
+
+			if rendered.Subject != test.subject {
+				t.Errorf("subject is %q, want %q", rendered.Subject, test.subject)
+			}
+
+			if !strings.Contains(rendered.Html, "Go &amp; Gin&#39;s Course") || strings.Contains(rendered.Html, "<Santoso>") {
+				t.Errorf("html is not escaped: %s", rendered.Html)
+			}
+
+			if !strings.Contains(rendered.Text, "Go & Gin's Course") {
+				t.Errorf("text is escaped: %s", rendered.Text)
+			}
+		})
+	}
+}
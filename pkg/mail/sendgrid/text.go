@@ -0,0 +1,44 @@
+package mail
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlHiddenPattern = regexp.MustCompile(`(?is)<(head|style|script)[^>]*>.*?</(head|style|script)>`)
+	htmlLinkPattern   = regexp.MustCompile(`(?is)<a\s[^>]*href="([^"]*)"[^>]*>(.*?)</a>`)
+	htmlBreakPattern  = regexp.MustCompile(`(?i)<br\s*/?>`)
+	htmlBlockPattern  = regexp.MustCompile(`(?i)</?(p|div|h[1-6]|table|ul|ol)[^>]*>`)
+	htmlRowPattern    = regexp.MustCompile(`(?i)</tr>`)
+	htmlItemPattern   = regexp.MustCompile(`(?i)<li[^>]*>`)
+	htmlCellPattern   = regexp.MustCompile(`(?i)</td>\s*<td[^>]*>`)
+	htmlTagPattern    = regexp.MustCompile(`<[^>]+>`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// HtmlToText converts a rendered email into a readable text/plain alternative
+func HtmlToText(content string) string {
+	text := htmlHiddenPattern.ReplaceAllString(content, "")
+	text = whitespacePattern.ReplaceAllString(text, " ")
+	text = htmlLinkPattern.ReplaceAllString(text, "$2 ($1)")
+	text = htmlBreakPattern.ReplaceAllString(text, "\n")
+	text = htmlCellPattern.ReplaceAllString(text, "\t")
+	text = htmlRowPattern.ReplaceAllString(text, "\n")
+	text = htmlBlockPattern.ReplaceAllString(text, "\n\n")
+	text = htmlItemPattern.ReplaceAllString(text, "\n- ")
+	text = htmlTagPattern.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+
+	text = blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+
+	return strings.TrimSpace(text)
+}
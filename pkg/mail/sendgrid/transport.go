@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sendgrid/sendgrid-go"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+)
+
+// Transport delivers an already rendered email
+type Transport interface {
+	Send(toEmail string, subject string, html string, text string) error
+}
+
+type sendgridTransport struct {
+}
+
+// Send implements Transport
+func (transport *sendgridTransport) Send(toEmail string, subject string, html string, text string) error {
+	from := mail.NewEmail(os.Getenv("MAIL_SENDER_NAME"), os.Getenv("MAIL_SENDER_NAME"))
+	to := mail.NewEmail(toEmail, toEmail)
+
+	message := mail.NewSingleEmail(from, subject, to, text, html)
+
+	client := sendgrid.NewSendClient(os.Getenv("MAIL_KEY"))
+	resp, err := client.Send(message)
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("sendgrid responded with %d: %s", resp.StatusCode, resp.Body)
+	}
+
+	return nil
+}
+
+func NewTransport() Transport {
+	return &sendgridTransport{}
+}
@@ -0,0 +1,92 @@
+package payment
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// FakePayment stands in for a real provider when working offline. Invoices
+// only live in memory, and payments are simulated by posting a callback
+// such as {"id": "fake-order-1", "status": "paid", "amount": 100000} with the
+// X-Callback-Token header to the callback endpoint.
+type FakePayment struct {
+	baseURL       string
+	callbackToken string
+	mutex         sync.Mutex
+	invoices      map[string]string
+}
+
+type fakeCallback struct {
+	ID         string `json:"id"`
+	ExternalID string `json:"external_id"`
+	Status     string `json:"status"`
+	Amount     int64  `json:"amount"`
+}
+
+// CreateInvoice implements Payment
+func (payment *FakePayment) CreateInvoice(request InvoiceRequest) (*Invoice, error) {
+	id := "fake-" + request.ExternalID
+
+	payment.mutex.Lock()
+	payment.invoices[id] = "pending"
+	payment.mutex.Unlock()
+
+	return &Invoice{
+		ID:  id,
+		URL: payment.baseURL + "/fake-checkout/" + id,
+	}, nil
+}
+
+// ExpireInvoice implements Payment
+func (payment *FakePayment) ExpireInvoice(id string) error {
+	payment.mutex.Lock()
+	defer payment.mutex.Unlock()
+
+	if payment.invoices[id] == StatusPaid {
+		return ErrInvoicePaid
+	}
+
+	payment.invoices[id] = StatusExpired
+
+	return nil
+}
+
+// ParseCallback implements Payment
+func (payment *FakePayment) ParseCallback(header http.Header, body []byte) (*Callback, error) {
+	if !validToken(payment.callbackToken, header.Get("X-Callback-Token")) {
+		return nil, ErrInvalidCallbackToken
+	}
+
+	var callback fakeCallback
+
+	if err := json.Unmarshal(body, &callback); err != nil {
+		return nil, err
+	}
+
+	status := strings.ToLower(callback.Status)
+
+	if status != StatusPaid && status != StatusExpired && status != StatusFailed {
+		status = ""
+	}
+
+	payment.mutex.Lock()
+	payment.invoices[callback.ID] = status
+	payment.mutex.Unlock()
+
+	return &Callback{
+		InvoiceID:  callback.ID,
+		ExternalID: callback.ExternalID,
+		Status:     status,
+		Amount:     callback.Amount,
+	}, nil
+}
+
+func NewFakePayment(baseURL string, callbackToken string) *FakePayment {
+	return &FakePayment{
+		baseURL:       strings.TrimSuffix(baseURL, "/"),
+		callbackToken: callbackToken,
+		invoices:      map[string]string{},
+	}
+}
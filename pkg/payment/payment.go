@@ -0,0 +1,86 @@
+package payment
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	StatusPaid    = "paid"
+	StatusExpired = "expired"
+	StatusFailed  = "failed"
+)
+
+var (
+	ErrInvalidCallbackToken = errors.New("invalid callback token")
+	ErrInvoicePaid          = errors.New("invoice has already been paid")
+)
+
+// InvoiceRequest describes the hosted payment page to open for an order.
+// ExternalID is our own reference, echoed back in callbacks.
+type InvoiceRequest struct {
+	ExternalID  string
+	Amount      int64
+	PayerEmail  string
+	Description string
+	Duration    time.Duration
+}
+
+// Invoice is a hosted payment page. ID is the reference of the provider.
+type Invoice struct {
+	ID  string
+	URL string
+}
+
+// Callback is a verified payment notification. Status is one of StatusPaid,
+// StatusExpired or StatusFailed, or empty for states we do not act on.
+type Callback struct {
+	InvoiceID  string
+	ExternalID string
+	Status     string
+	Amount     int64
+}
+
+// Payment opens and closes hosted payment pages and reads their callbacks
+type Payment interface {
+	CreateInvoice(request InvoiceRequest) (*Invoice, error)
+	// ExpireInvoice closes an unpaid invoice so it can no longer be paid.
+	// Invoices that are already expired or unknown to the provider count as
+	// closed, paid ones fail with ErrInvoicePaid.
+	ExpireInvoice(id string) error
+	// ParseCallback verifies the callback token and decodes the body, failing
+	// with ErrInvalidCallbackToken for forged requests
+	ParseCallback(header http.Header, body []byte) (*Callback, error)
+}
+
+// NewPayment picks the provider from PAYMENT_PROVIDER, "xendit" or "fake".
+// Both check callbacks against PAYMENT_CALLBACK_TOKEN. The provider must be
+// named explicitly, so a missing setting stops the app at startup instead
+// of serving fake checkouts.
+func NewPayment() Payment {
+	switch provider := os.Getenv("PAYMENT_PROVIDER"); provider {
+	case "xendit":
+		return NewXenditPayment(XenditConfig{
+			BaseURL:       os.Getenv("XENDIT_BASE_URL"),
+			SecretKey:     os.Getenv("XENDIT_SECRET_KEY"),
+			CallbackToken: os.Getenv("PAYMENT_CALLBACK_TOKEN"),
+		})
+	case "fake":
+		return NewFakePayment(os.Getenv("APP_URL"), os.Getenv("PAYMENT_CALLBACK_TOKEN"))
+	default:
+		panic(fmt.Sprintf("Unknown PAYMENT_PROVIDER %q, use xendit or fake", provider))
+	}
+}
+
+// validToken compares tokens in constant time and never accepts an empty one
+func validToken(expected string, actual string) bool {
+	if expected == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(actual)) == 1
+}
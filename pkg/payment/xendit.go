@@ -0,0 +1,194 @@
+package payment
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type XenditConfig struct {
+	// BaseURL defaults to https://api.xendit.co
+	BaseURL       string
+	SecretKey     string
+	CallbackToken string
+}
+
+// XenditPayment opens Xendit invoices through the REST API
+type XenditPayment struct {
+	config XenditConfig
+	client *http.Client
+}
+
+type xenditError struct {
+	statusCode int
+	message    string
+}
+
+func (err *xenditError) Error() string {
+	return err.message
+}
+
+type xenditInvoice struct {
+	ID         string  `json:"id"`
+	ExternalID string  `json:"external_id"`
+	Status     string  `json:"status"`
+	InvoiceURL string  `json:"invoice_url"`
+	Amount     float64 `json:"amount"`
+	PaidAmount float64 `json:"paid_amount"`
+}
+
+// CreateInvoice implements Payment
+func (payment *XenditPayment) CreateInvoice(request InvoiceRequest) (*Invoice, error) {
+	body := map[string]interface{}{
+		"external_id": request.ExternalID,
+		"amount":      request.Amount,
+		"description": request.Description,
+	}
+
+	if request.PayerEmail != "" {
+		body["payer_email"] = request.PayerEmail
+	}
+
+	if request.Duration > 0 {
+		body["invoice_duration"] = int64(request.Duration.Seconds())
+	}
+
+	var invoice xenditInvoice
+
+	if err := payment.do(http.MethodPost, "/v2/invoices", body, &invoice); err != nil {
+		return nil, err
+	}
+
+	return &Invoice{
+		ID:  invoice.ID,
+		URL: invoice.InvoiceURL,
+	}, nil
+}
+
+// ExpireInvoice implements Payment
+// Xendit refuses to expire invoices that are no longer pending, so on
+// failure the invoice is looked up to tell expired from paid ones.
+func (payment *XenditPayment) ExpireInvoice(id string) error {
+	err := payment.do(http.MethodPost, "/invoices/"+url.PathEscape(id)+"/expire!", nil, nil)
+
+	if err == nil {
+		return nil
+	}
+
+	var errXendit *xenditError
+
+	if errors.As(err, &errXendit) && errXendit.statusCode == http.StatusNotFound {
+		return nil
+	}
+
+	var invoice xenditInvoice
+
+	if errGet := payment.do(http.MethodGet, "/v2/invoices/"+url.PathEscape(id), nil, &invoice); errGet != nil {
+		return err
+	}
+
+	switch strings.ToUpper(invoice.Status) {
+	case "EXPIRED":
+		return nil
+	case "PAID", "SETTLED":
+		return ErrInvoicePaid
+	}
+
+	return err
+}
+
+// ParseCallback implements Payment for invoice callbacks, which carry the
+// verification token in the X-Callback-Token header
+func (payment *XenditPayment) ParseCallback(header http.Header, body []byte) (*Callback, error) {
+	if !validToken(payment.config.CallbackToken, header.Get("X-Callback-Token")) {
+		return nil, ErrInvalidCallbackToken
+	}
+
+	var invoice xenditInvoice
+
+	if err := json.Unmarshal(body, &invoice); err != nil {
+		return nil, err
+	}
+
+	callback := &Callback{
+		InvoiceID:  invoice.ID,
+		ExternalID: invoice.ExternalID,
+		Amount:     int64(invoice.PaidAmount),
+	}
+
+	switch strings.ToUpper(invoice.Status) {
+	case "PAID", "SETTLED":
+		callback.Status = StatusPaid
+	case "EXPIRED":
+		callback.Status = StatusExpired
+	case "FAILED":
+		callback.Status = StatusFailed
+	}
+
+	return callback, nil
+}
+
+func (payment *XenditPayment) do(method string, path string, body interface{}, result interface{}) error {
+	var reader io.Reader
+
+	if body != nil {
+		encoded, err := json.Marshal(body)
+
+		if err != nil {
+			return err
+		}
+
+		reader = bytes.NewReader(encoded)
+	}
+
+	request, err := http.NewRequest(method, payment.config.BaseURL+path, reader)
+
+	if err != nil {
+		return err
+	}
+
+	request.SetBasicAuth(payment.config.SecretKey, "")
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := payment.client.Do(request)
+
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+
+		return &xenditError{
+			statusCode: response.StatusCode,
+			message:    fmt.Sprintf("xendit %s %s responded with %d: %s", method, path, response.StatusCode, message),
+		}
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+func NewXenditPayment(config XenditConfig) *XenditPayment {
+	if config.BaseURL == "" {
+		config.BaseURL = "https://api.xendit.co"
+	}
+
+	config.BaseURL = strings.TrimSuffix(config.BaseURL, "/")
+
+	return &XenditPayment{
+		config: config,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
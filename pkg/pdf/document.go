@@ -0,0 +1,163 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Page sizes in points
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Document is a single page PDF drawn with the base 14 Helvetica fonts.
+// Coordinates start at the bottom left corner of the page.
+type Document struct {
+	width   float64
+	height  float64
+	content bytes.Buffer
+}
+
+func NewDocument(width float64, height float64) *Document {
+	return &Document{width: width, height: height}
+}
+
+func (document *Document) Width() float64 {
+	return document.width
+}
+
+func (document *Document) Height() float64 {
+	return document.height
+}
+
+// Text draws s with its baseline starting at x, y
+func (document *Document) Text(font string, size float64, x float64, y float64, s string) {
+	fmt.Fprintf(&document.content, "BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+		fontResource(font), number(size), number(x), number(y), escape(s))
+}
+
+// TextCenter draws s horizontally centred on the page
+func (document *Document) TextCenter(font string, size float64, y float64, s string) {
+	document.Text(font, size, (document.width-TextWidth(font, size, s))/2, y, s)
+}
+
+// FitSize shrinks size until s fits in maxWidth
+func FitSize(font string, size float64, maxWidth float64, s string) float64 {
+	for size > 6 && TextWidth(font, size, s) > maxWidth {
+		size -= 0.5
+	}
+
+	return size
+}
+
+// SetFillColor sets the colour of text and filled shapes, components 0..1
+func (document *Document) SetFillColor(r float64, g float64, b float64) {
+	fmt.Fprintf(&document.content, "%s %s %s rg\n", number(r), number(g), number(b))
+}
+
+// SetStrokeColor sets the colour of lines, components 0..1
+func (document *Document) SetStrokeColor(r float64, g float64, b float64) {
+	fmt.Fprintf(&document.content, "%s %s %s RG\n", number(r), number(g), number(b))
+}
+
+func (document *Document) Line(x1 float64, y1 float64, x2 float64, y2 float64, lineWidth float64) {
+	fmt.Fprintf(&document.content, "%s w %s %s m %s %s l S\n",
+		number(lineWidth), number(x1), number(y1), number(x2), number(y2))
+}
+
+func (document *Document) Rect(x float64, y float64, w float64, h float64, lineWidth float64) {
+	fmt.Fprintf(&document.content, "%s w %s %s %s %s re S\n",
+		number(lineWidth), number(x), number(y), number(w), number(h))
+}
+
+func (document *Document) FillRect(x float64, y float64, w float64, h float64) {
+	fmt.Fprintf(&document.content, "%s %s %s %s re f\n", number(x), number(y), number(w), number(h))
+}
+
+// Bytes renders the finished file
+func (document *Document) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1 catalog, 2 pages, 3 page, 4 content, 5.. fonts
+	var fonts strings.Builder
+
+	for i := range fontOrder {
+		fmt.Fprintf(&fonts, "/F%d %d 0 R ", i+1, i+5)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	object(fmt.Sprintf(
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Contents 4 0 R /Resources << /Font << %s>> >> >>",
+		number(document.width), number(document.height), fonts.String(),
+	))
+	object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", document.content.Len(), document.content.String()))
+
+	for _, font := range fontOrder {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", font))
+	}
+
+	xref := out.Len()
+
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+func fontResource(font string) string {
+	for i, name := range fontOrder {
+		if name == font {
+			return fmt.Sprintf("F%d", i+1)
+		}
+	}
+
+	return "F1"
+}
+
+// escape encodes s as a WinAnsi literal string. Latin-1 characters map to
+// the same byte, anything else becomes "?".
+func escape(s string) string {
+	var escaped strings.Builder
+
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			escaped.WriteByte('\\')
+			escaped.WriteRune(r)
+		case r >= 32 && r <= 126:
+			escaped.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&escaped, "\\%03o", r)
+		default:
+			escaped.WriteByte('?')
+		}
+	}
+
+	return escaped.String()
+}
+
+func number(value float64) string {
+	formatted := strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", value), "0"), ".")
+
+	if formatted == "" || formatted == "-0" {
+		return "0"
+	}
+
+	return formatted
+}
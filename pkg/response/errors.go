@@ -1,6 +1,31 @@
 package response
 
+import "errors"
+
 type Error struct {
 	Code uint
 	Err error
-}
\ No newline at end of file
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// FromError recovers the *Error carried through a plain error, e.g. the one
+// returned by gorm's Transaction, falling back to a 500
+func FromError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var responseError *Error
+
+	if errors.As(err, &responseError) {
+		return responseError
+	}
+
+	return &Error{
+		Code: 500,
+		Err:  err,
+	}
+}
@@ -0,0 +1,286 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3TimeFormat      = "20060102T150405Z"
+	s3DateFormat      = "20060102"
+)
+
+type S3Config struct {
+	// Endpoint is the scheme and host, e.g. https://s3.ap-southeast-1.amazonaws.com
+	// or http://localhost:9000 for MinIO
+	Endpoint string
+	Region   string
+	// Bucket keeps the public objects, see PublicPrefix
+	Bucket string
+	// PrivateBucket keeps the private objects. It defaults to Bucket, whose
+	// policy must then only allow anonymous reads under PublicPrefix.
+	PrivateBucket string
+	AccessKey     string
+	SecretKey     string
+	// PublicURL prefixes public object URLs, e.g. a CDN in front of the bucket
+	PublicURL string
+	// UsePathStyle addresses objects as endpoint/bucket/key, required by MinIO
+	UsePathStyle bool
+}
+
+// S3Storage talks to any S3 compatible service with AWS Signature Version 4
+type S3Storage struct {
+	config S3Config
+	client *http.Client
+}
+
+// Put implements Storage
+func (storage *S3Storage) Put(key string, body io.Reader, size int64, contentType string) error {
+	request, err := http.NewRequest(http.MethodPut, storage.objectURL(key), body)
+
+	if err != nil {
+		return err
+	}
+
+	request.ContentLength = size
+	request.Header.Set("Content-Type", contentType)
+
+	return storage.do(request)
+}
+
+// Delete implements Storage
+func (storage *S3Storage) Delete(key string) error {
+	request, err := http.NewRequest(http.MethodDelete, storage.objectURL(key), nil)
+
+	if err != nil {
+		return err
+	}
+
+	return storage.do(request)
+}
+
+// URL implements Storage
+func (storage *S3Storage) URL(key string) string {
+	if storage.config.PublicURL != "" {
+		return strings.TrimSuffix(storage.config.PublicURL, "/") + "/" + encodePath(key)
+	}
+
+	return storage.objectURL(key)
+}
+
+// SignedURL implements Storage with a presigned GET request
+func (storage *S3Storage) SignedURL(key string, expiresIn time.Duration) (string, error) {
+	return storage.presign(key, expiresIn, time.Now().UTC())
+}
+
+func (storage *S3Storage) presign(key string, expiresIn time.Duration, now time.Time) (string, error) {
+	objectURL, err := url.Parse(storage.objectURL(key))
+
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", s3Algorithm)
+	query.Set("X-Amz-Credential", storage.config.AccessKey+"/"+storage.scope(now))
+	query.Set("X-Amz-Date", now.Format(s3TimeFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiresIn.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		objectURL.EscapedPath(),
+		canonicalQuery(query),
+		"host:" + objectURL.Host + "\n",
+		"host",
+		s3UnsignedPayload,
+	}, "\n")
+
+	query.Set("X-Amz-Signature", storage.signature(now, canonicalRequest))
+	objectURL.RawQuery = canonicalQuery(query)
+
+	return objectURL.String(), nil
+}
+
+func (storage *S3Storage) do(request *http.Request) error {
+	storage.signRequest(request, time.Now().UTC())
+
+	response, err := storage.client.Do(request)
+
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+
+		return fmt.Errorf("s3 %s %s responded with %d: %s", request.Method, request.URL.Path, response.StatusCode, message)
+	}
+
+	return nil
+}
+
+// signRequest adds the Authorization header. The payload is left unsigned so
+// large uploads can be streamed without hashing them first.
+func (storage *S3Storage) signRequest(request *http.Request, now time.Time) {
+	request.Header.Set("Host", request.URL.Host)
+	request.Header.Set("X-Amz-Date", now.Format(s3TimeFormat))
+	request.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	names := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+
+	if request.Header.Get("Content-Type") != "" {
+		names = append(names, "content-type")
+	}
+
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+
+	for _, name := range names {
+		value := request.Header.Get(name)
+
+		if name == "host" {
+			value = request.URL.Host
+		}
+
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		canonicalQuery(request.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+
+	request.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm,
+		storage.config.AccessKey,
+		storage.scope(now),
+		signedHeaders,
+		storage.signature(now, canonicalRequest),
+	))
+}
+
+func (storage *S3Storage) signature(now time.Time, canonicalRequest string) string {
+	hashedRequest := sha256.Sum256([]byte(canonicalRequest))
+
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		now.Format(s3TimeFormat),
+		storage.scope(now),
+		hex.EncodeToString(hashedRequest[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+storage.config.SecretKey), now.Format(s3DateFormat))
+	key = hmacSHA256(key, storage.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func (storage *S3Storage) scope(now time.Time) string {
+	return now.Format(s3DateFormat) + "/" + storage.config.Region + "/s3/aws4_request"
+}
+
+func (storage *S3Storage) objectURL(key string) string {
+	endpoint, _ := url.Parse(strings.TrimSuffix(storage.config.Endpoint, "/"))
+	bucket := storage.bucket(key)
+
+	if storage.config.UsePathStyle {
+		return endpoint.Scheme + "://" + endpoint.Host + "/" + bucket + "/" + encodePath(key)
+	}
+
+	return endpoint.Scheme + "://" + bucket + "." + endpoint.Host + "/" + encodePath(key)
+}
+
+func (storage *S3Storage) bucket(key string) string {
+	if !IsPublic(key) && storage.config.PrivateBucket != "" {
+		return storage.config.PrivateBucket
+	}
+
+	return storage.config.Bucket
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
+
+// canonicalQuery encodes query parameters sorted by name as SigV4 requires
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+
+	for key := range query {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	parts := []string{}
+
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+
+		for _, value := range values {
+			parts = append(parts, encodeURIComponent(key)+"="+encodeURIComponent(value))
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
+
+func encodePath(key string) string {
+	segments := strings.Split(key, "/")
+
+	for i, segment := range segments {
+		segments[i] = encodeURIComponent(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// encodeURIComponent escapes everything except the SigV4 unreserved characters
+func encodeURIComponent(value string) string {
+	var encoded strings.Builder
+
+	for _, b := range []byte(value) {
+		if (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') || b == '-' || b == '_' || b == '.' || b == '~' {
+			encoded.WriteByte(b)
+		} else {
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+
+	return encoded.String()
+}
+
+func NewS3Storage(config S3Config) *S3Storage {
+	return &S3Storage{
+		config: config,
+		client: &http.Client{Timeout: 30 * time.Minute},
+	}
+}
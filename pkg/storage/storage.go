@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// PublicPrefix is the only prefix readable without a signed URL. Videos,
+// certificates and every other key are private.
+const PublicPrefix = "images/"
+
+// Storage keeps uploaded media. Keys are slash separated paths such as
+// "images/products/abc.jpg".
+type Storage interface {
+	Put(key string, body io.Reader, size int64, contentType string) error
+	Delete(key string) error
+	// URL is the public address of the object
+	URL(key string) string
+	// SignedURL grants temporary read access to a private object
+	SignedURL(key string, expiresIn time.Duration) (string, error)
+}
+
+// NewStorage picks the driver from STORAGE_DRIVER, "local" (default) or "s3"
+func NewStorage() Storage {
+	if os.Getenv("STORAGE_DRIVER") == "s3" {
+		return NewS3Storage(S3Config{
+			Endpoint:      os.Getenv("S3_ENDPOINT"),
+			Region:        os.Getenv("S3_REGION"),
+			Bucket:        os.Getenv("S3_BUCKET"),
+			PrivateBucket: os.Getenv("S3_PRIVATE_BUCKET"),
+			AccessKey:     os.Getenv("S3_ACCESS_KEY"),
+			SecretKey:     os.Getenv("S3_SECRET_KEY"),
+			PublicURL:     os.Getenv("S3_PUBLIC_URL"),
+			UsePathStyle:  os.Getenv("S3_USE_PATH_STYLE") == "true",
+		})
+	}
+
+	root := os.Getenv("STORAGE_LOCAL_ROOT")
+
+	if root == "" {
+		root = "storage"
+	}
+
+	return NewLocalStorage(root, os.Getenv("APP_URL"), os.Getenv("STORAGE_SIGNING_KEY"))
+}
+
+// IsPublic tells whether the object may be read without a signed URL
+func IsPublic(key string) bool {
+	return strings.HasPrefix(key, PublicPrefix)
+}
@@ -0,0 +1,114 @@
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/jpeg"
+	"io"
+
+	// decoders registered for image.Decode
+	_ "image/gif"
+	_ "image/png"
+)
+
+const (
+	jpegQuality = 80
+	// MaxPixels caps width x height of the images decoded, a small file can
+	// declare dimensions that take gigabytes once decoded
+	MaxPixels = 24_000_000
+)
+
+var ErrTooManyPixels = errors.New("image has too many pixels")
+
+// Generate decodes a jpeg, png or gif image and returns a jpeg scaled down to
+// fit within maxWidth. Smaller images are re-encoded at their original size.
+// The dimensions are read from the header first and images larger than
+// MaxPixels fail with ErrTooManyPixels before they are decoded.
+func Generate(src io.Reader, maxWidth int) (*bytes.Buffer, error) {
+	header := new(bytes.Buffer)
+
+	config, _, err := image.DecodeConfig(io.TeeReader(src, header))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Width <= 0 || config.Height <= 0 || config.Width > MaxPixels/config.Height {
+		return nil, ErrTooManyPixels
+	}
+
+	img, _, err := image.Decode(io.MultiReader(header, src))
+
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width > maxWidth {
+		height = height * maxWidth / width
+		width = maxWidth
+
+		if height < 1 {
+			height = 1
+		}
+	}
+
+	img = resize(img, width, height)
+
+	buffer := new(bytes.Buffer)
+
+	if err := jpeg.Encode(buffer, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, err
+	}
+
+	return buffer, nil
+}
+
+// resize downscales with a box filter, averaging every source pixel that
+// falls inside each destination pixel. Transparent areas are flattened onto
+// white since jpeg has no alpha channel.
+func resize(src image.Image, width int, height int) image.Image {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := bounds.Min.Y + (y+1)*bounds.Dy()/height
+
+		if y1 == y0 {
+			y1++
+		}
+
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := bounds.Min.X + (x+1)*bounds.Dx()/width
+
+			if x1 == x0 {
+				x1++
+			}
+
+			var r, g, b, count uint64
+
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r += uint64(pr + 0xffff - pa)
+					g += uint64(pg + 0xffff - pa)
+					b += uint64(pb + 0xffff - pa)
+					count++
+				}
+			}
+
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = uint8(r / count >> 8)
+			dst.Pix[offset+1] = uint8(g / count >> 8)
+			dst.Pix[offset+2] = uint8(b / count >> 8)
+			dst.Pix[offset+3] = 0xff
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// SearchTerms splits user input into lowercase words, dropping anything that
+// could be read as a MySQL boolean mode operator
+func SearchTerms(input string) []string {
+	words := strings.FieldsFunc(strings.ToLower(input), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := map[string]bool{}
+	terms := []string{}
+
+	for _, word := range words {
+		if !seen[word] {
+			seen[word] = true
+			terms = append(terms, word)
+		}
+	}
+
+	return terms
+}
+
+// BooleanPrefixQuery builds a MySQL boolean mode query matching each term as a
+// prefix. With requireAll every term must match.
+func BooleanPrefixQuery(terms []string, requireAll bool) string {
+	parts := make([]string, len(terms))
+
+	for i, term := range terms {
+		if requireAll {
+			parts[i] = "+" + term + "*"
+		} else {
+			parts[i] = term + "*"
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Highlight HTML-escapes text and wraps words starting with any of the terms
+// in <mark> tags
+func Highlight(text string, terms []string) string {
+	escaped := html.EscapeString(text)
+
+	if len(terms) == 0 {
+		return escaped
+	}
+
+	quoted := make([]string, len(terms))
+
+	for i, term := range terms {
+		quoted[i] = regexp.QuoteMeta(html.EscapeString(term))
+	}
+
+	pattern := regexp.MustCompile(`(?i)(^|[^\p{L}\p{N}])((?:` + strings.Join(quoted, "|") + `)[\p{L}\p{N}]*)`)
+
+	return pattern.ReplaceAllString(escaped, "$1<mark>$2</mark>")
+}
+
+// EditDistance returns the number of single letter insertions, deletions,
+// substitutions or adjacent swaps needed to turn a into b
+func EditDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	rows := make([][]int, len(ra)+1)
+
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			distance := rows[i-1][j] + 1
+
+			if rows[i][j-1]+1 < distance {
+				distance = rows[i][j-1] + 1
+			}
+
+			if rows[i-1][j-1]+cost < distance {
+				distance = rows[i-1][j-1] + cost
+			}
+
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] && rows[i-2][j-2]+1 < distance {
+				distance = rows[i-2][j-2] + 1
+			}
+
+			rows[i][j] = distance
+		}
+	}
+
+	return rows[len(ra)][len(rb)]
+}
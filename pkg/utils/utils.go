@@ -3,6 +3,9 @@ package utils
 import (
 	"math/rand"
 
+	oauthDto "e-course-management/internal/oauth/dto"
+
+	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
@@ -35,7 +38,63 @@ func Paginate(offset int, limit int) func(db *gorm.DB) *gorm.DB {
 			pageSize = 10
 		}
 
-		offset := (page - 1) * limit
+		offset := (page - 1) * pageSize
 		return db.Offset(offset).Limit(pageSize)
 	}
 }
+
+// SoftDelete stores who deleted the row in updated_by before soft deleting it
+func SoftDelete(db *gorm.DB, model interface{}, deletedBy *int64) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(model).Update("updated_by", deletedBy).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(model).Error
+	})
+}
+
+// GetCurrentUser returns the claims stored by middleware.AuthJwt
+func GetCurrentUser(ctx *gin.Context) *oauthDto.ClaimsResponse {
+	user, _ := ctx.Get("user")
+
+	return user.(*oauthDto.ClaimsResponse)
+}
+
+// GetOptionalUser returns the claims stored by middleware.OptionalAuthJwt, or
+// nil for anonymous requests
+func GetOptionalUser(ctx *gin.Context) *oauthDto.ClaimsResponse {
+	user, exists := ctx.Get("user")
+
+	if !exists {
+		return nil
+	}
+
+	return user.(*oauthDto.ClaimsResponse)
+}
+
+const guestCartCookie = "guest_cart"
+
+// GetGuestCart returns the guest cart token sent in the X-Guest-Cart header or
+// the guest_cart cookie
+func GetGuestCart(ctx *gin.Context) string {
+	if token := ctx.GetHeader("X-Guest-Cart"); token != "" {
+		return token
+	}
+
+	token, _ := ctx.Cookie(guestCartCookie)
+
+	return token
+}
+
+// SetGuestCart stores the guest cart token in a cookie for 30 days, or removes
+// the cookie when token is empty
+func SetGuestCart(ctx *gin.Context, token string) {
+	maxAge := 30 * 24 * 60 * 60
+
+	if token == "" {
+		maxAge = -1
+	}
+
+	ctx.SetCookie(guestCartCookie, token, maxAge, "/", "", ctx.Request.TLS != nil, true)
+}
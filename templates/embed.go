@@ -0,0 +1,8 @@
+package templates
+
+import "embed"
+
+// Emails holds the email layouts and their per-locale variants
+//
+//go:embed emails
+var Emails embed.FS